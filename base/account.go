@@ -1,207 +1,269 @@
 package base
 
 import (
-	"bytes"
 	"context"
-	"crypto/ecdsa"
-	"encoding/hex"
-	"encoding/json"
 	"fmt"
-	"io"
 	"math/big"
-	"net/http"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-type BasenamesContract struct {
+// Contract pairs a deployed contract address with its parsed ABI.
+type Contract struct {
 	Address common.Address
 	ABI     abi.ABI
-	Client  *ethclient.Client
 }
 
-func (c *Client) NewBasenamesContract() (*BasenamesContract, error) {
-
-	client, err := ethclient.Dial(c.RpcURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to the Ethereum client: %v", err)
-	}
-
-	basenamesABI, err := abi.JSON(strings.NewReader(BasenamesABI))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse basenames ABI: %v", err)
-	}
+func (c *Client) NewBasenamesContract() *Contract {
+	return &Contract{Address: c.Network.Contracts.Registrar, ABI: basenamesABI}
+}
 
-	// Hardcode the Basenames contract address
-	contractAddress := common.HexToAddress("0x03c4738Ee98aE44591e1A4A4F3CaB6641d95DD9a")
+func (c *Client) NewRegistrarControllerContract() *Contract {
+	return &Contract{Address: c.Network.Contracts.RegistrarController, ABI: registrarControllerABI}
+}
 
-	return &BasenamesContract{
-		Address: contractAddress,
-		ABI:     basenamesABI,
-		Client:  client,
-	}, nil
+func (c *Client) NewL2ResolverContract() *Contract {
+	return c.NewResolverContract(c.Network.Contracts.L2Resolver)
 }
 
-func (c *Client) GetBlock() (string, error) {
-	url := c.RpcURL
+func (c *Client) NewRegistryContract() *Contract {
+	return &Contract{Address: c.Network.Contracts.Registry, ABI: registryABI}
+}
 
-	// Create the JSON request payload
-	payload := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"id":      1,
-		"method":  "eth_blockNumber",
-		"params":  []interface{}{},
-	}
+func (c *Client) NewMulticall3Contract() *Contract {
+	return &Contract{Address: c.Network.Contracts.Multicall3, ABI: multicall3ABI}
+}
 
-	// Convert the payload to JSON
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		return "", err
-	}
+// NewResolverContract binds the L2 resolver ABI to an arbitrary resolver address.
+func (c *Client) NewResolverContract(address common.Address) *Contract {
+	return &Contract{Address: address, ABI: l2ResolverABI}
+}
 
-	// Create a new HTTP request
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+// Call packs a read-only method call, executes it and unpacks the outputs.
+func (c *Client) Call(ctx context.Context, contract *Contract, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := contract.ABI.Pack(method, args...)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to encode %s call: %v", method, err)
 	}
 
-	//set the headers of the client's http client
-	c.setHeaders(req)
-
-	// Send the request
-	resp, err := c.HttpClient.Do(req)
+	result, err := c.ReadContract(ctx, contract.Address, data)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
+	outputs, err := contract.ABI.Unpack(method, result)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to decode %s result: %v", method, err)
 	}
 
-	if err := json.Unmarshal(body, &GetBlockResponse); err != nil {
-		return "", fmt.Errorf("failed to parse JSON response: %v", err)
-	}
+	return outputs, nil
+}
 
-	blockNumber, success := new(big.Int).SetString(GetBlockResponse.Result[2:], 16)
-	if !success {
-		return "", fmt.Errorf("failed to convert block number to big.Int")
+func (c *Client) GetBlock(ctx context.Context) (string, error) {
+	var blockNumber uint64
+	err := c.withClient(ctx, func(client *ethclient.Client) error {
+		var err error
+		blockNumber, err = client.BlockNumber(ctx)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get block number: %w", err)
 	}
 
-	return blockNumber.String(), nil
-
+	return strconv.FormatUint(blockNumber, 10), nil
 }
 
 // get the balance of the account
-func (c *Client) GetBalance(address string) (string, error) {
-	// Dial the Ethereum client
-	client, err := ethclient.Dial(c.RpcURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to connect to the Ethereum client: %v", err)
-	}
-	defer client.Close()
-
+func (c *Client) GetBalance(ctx context.Context, address string) (string, error) {
 	// Convert address string to common.Address
 	account := common.HexToAddress(address)
 
 	// Get the balance
-	balance, err := client.BalanceAt(context.Background(), account, nil)
+	var balance *big.Int
+	err := c.withClient(ctx, func(client *ethclient.Client) error {
+		var err error
+		balance, err = client.BalanceAt(ctx, account, nil)
+		return err
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to get balance: %v", err)
+		return "", fmt.Errorf("failed to get balance: %w", err)
 	}
 
-	// Convert balance to string
-	balanceStr := balance.String()
-
-	fmt.Printf("Balance of %s: %s wei\n", address, balanceStr)
-	return balanceStr, nil
+	return balance.String(), nil
 }
 
-func (c *Client) ReadContract(to common.Address, data []byte) ([]byte, error) {
-	client, err := ethclient.Dial(c.RpcURL)
+// IsContract reports whether code is deployed at the address.
+func (c *Client) IsContract(ctx context.Context, address common.Address) (bool, error) {
+	var code []byte
+	err := c.withClient(ctx, func(client *ethclient.Client) error {
+		var err error
+		code, err = client.CodeAt(ctx, address, nil)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to the Ethereum client: %v", err)
+		return false, fmt.Errorf("failed to get code: %w", err)
 	}
-	defer client.Close()
+	return len(code) > 0, nil
+}
 
+func (c *Client) ReadContract(ctx context.Context, to common.Address, data []byte) ([]byte, error) {
 	msg := ethereum.CallMsg{
 		To:   &to,
 		Data: data,
 	}
 
-	result, err := client.CallContract(context.Background(), msg, nil)
+	var result []byte
+	err := c.withClient(ctx, func(client *ethclient.Client) error {
+		var err error
+		result, err = client.CallContract(ctx, msg, nil)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to call contract: %v", err)
+		return nil, fmt.Errorf("failed to call contract: %w", DecodeRevert(err))
 	}
 
 	return result, nil
 }
 
-func (c *Client) WriteContract(to common.Address, data []byte, value *big.Int) (string, error) {
-	client, err := ethclient.Dial(c.RpcURL)
+// WriteContract sends a transaction and waits for its receipt according to
+// the client's TxOptions.
+func (c *Client) WriteContract(ctx context.Context, to common.Address, data []byte, value *big.Int) (*TxResult, error) {
+	hash, err := c.SendTransaction(ctx, to, data, value)
 	if err != nil {
-		return "", fmt.Errorf("failed to connect to the Ethereum client: %v", err)
+		return nil, err
 	}
-	defer client.Close()
 
-	privateKey, err := crypto.HexToECDSA(c.PrivateKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse private key: %v", err)
+	// Progress goes to stderr so stdout only carries command output
+	fmt.Fprintf(os.Stderr, "Transaction sent: %s\n", hash.Hex())
+	return c.WaitForReceipt(ctx, hash)
+}
+
+// SendTransaction signs and broadcasts a transaction and returns its hash.
+func (c *Client) SendTransaction(ctx context.Context, to common.Address, data []byte, value *big.Int) (common.Hash, error) {
+	if !c.CanSign() {
+		return common.Hash{}, Errorf(KindInvalidInput, "no signer loaded, set %s or a keystore signer to send transactions", BASENAMES_PRIVATE_KEY)
 	}
+	fromAddress := common.HexToAddress(c.Address)
 
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return "", fmt.Errorf("cannot assert type: publicKey is not of type *ecdsa.PublicKey")
+	if value == nil {
+		value = big.NewInt(0) // Default value is zero
 	}
 
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	// Build the unsigned transaction. This only reads chain state, so the
+	// whole step can be retried on another endpoint.
+	var tx *types.Transaction
+	err := c.withClient(ctx, func(client *ethclient.Client) error {
+		var err error
+		tx, err = c.buildTransaction(ctx, client, fromAddress, to, data, value)
+		return err
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to get nonce: %v", err)
+		return common.Hash{}, err
 	}
 
-	if value == nil {
-		value = big.NewInt(0) // Default value is zero
+	signedTx, err := c.signTransaction(ctx, tx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
-	gasLimit := uint64(21000) // You might want to estimate this
-	gasPrice, err := client.SuggestGasPrice(context.Background())
+	// Rebroadcasting the same signed transaction is safe. When a retry follows
+	// a dropped response the node may already have it, which counts as sent.
+	// If it was already mined, the node reports the nonce as too low instead,
+	// so look the transaction up before failing.
+	retry := false
+	err = c.withClient(ctx, func(client *ethclient.Client) error {
+		err := client.SendTransaction(ctx, signedTx)
+		if err == nil {
+			return nil
+		}
+		message := strings.ToLower(err.Error())
+		if strings.Contains(message, "already known") {
+			return nil
+		}
+		if retry || strings.Contains(message, "nonce too low") {
+			if _, _, lookupErr := client.TransactionByHash(ctx, signedTx.Hash()); lookupErr == nil {
+				return nil
+			}
+		}
+		retry = true
+		return err
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to suggest gas price: %v", err)
+		return common.Hash{}, fmt.Errorf("failed to send transaction: %w", err)
 	}
 
-	tx := types.NewTransaction(nonce, to, value, gasLimit, gasPrice, data)
+	return signedTx.Hash(), nil
+}
 
-	chainID, err := client.NetworkID(context.Background())
+// buildTransaction simulates the call and fills in the nonce, fees and gas
+// limit of an unsigned dynamic fee transaction. The chain ID is the network's,
+// which withClient has verified against the endpoint.
+func (c *Client) buildTransaction(ctx context.Context, client *ethclient.Client, from, to common.Address, data []byte, value *big.Int) (*types.Transaction, error) {
+	nonce, err := client.PendingNonceAt(ctx, from)
 	if err != nil {
-		return "", fmt.Errorf("failed to get network ID: %v", err)
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
 	}
 
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign transaction: %v", err)
+	// Dry-run the call so reverts surface with a decoded reason before anything is signed
+	callMsg := ethereum.CallMsg{
+		From:  from,
+		To:    &to,
+		Value: value,
+		Data:  data,
+	}
+	if _, err := client.CallContract(ctx, callMsg, nil); err != nil {
+		return nil, fmt.Errorf("transaction simulation failed: %w", DecodeRevert(err))
 	}
 
-	ts := types.Transactions{signedTx}
-	b := new(bytes.Buffer)
-	ts.EncodeIndex(0, b)
-	rawTxBytes := b.Bytes()
-	rawTxHex := hex.EncodeToString(rawTxBytes)
+	tipCap := c.TxOptions.PriorityFee
+	if tipCap == nil {
+		tipCap, err = client.SuggestGasTipCap(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to suggest gas tip cap: %w", err)
+		}
+	}
 
-	err = client.SendTransaction(context.Background(), signedTx)
-	if err != nil {
-		return "", fmt.Errorf("failed to send transaction: %v", err)
+	feeCap := c.TxOptions.MaxFee
+	if feeCap == nil {
+		header, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest header: %w", err)
+		}
+		if header.BaseFee == nil {
+			return nil, fmt.Errorf("latest block has no base fee, EIP-1559 is not supported by this chain")
+		}
+		// Leave room for the base fee to double before the transaction is included
+		feeCap = new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), tipCap)
+	}
+	if feeCap.Cmp(tipCap) < 0 {
+		return nil, Errorf(KindInvalidInput, "max fee %s wei is lower than priority fee %s wei", feeCap, tipCap)
+	}
+
+	gasLimit := c.TxOptions.GasLimit
+	if gasLimit == 0 {
+		estimate, err := client.EstimateGas(ctx, callMsg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: %w", DecodeRevert(err))
+		}
+		gasLimit = estimate + estimate*c.TxOptions.GasMarginPercent/100
 	}
 
-	fmt.Printf("Transaction sent: %s\n", rawTxHex)
-	return rawTxHex, nil
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   new(big.Int).SetUint64(c.Network.ChainID),
+		Nonce:     nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       gasLimit,
+		To:        &to,
+		Value:     value,
+		Data:      data,
+	})
+	return tx, nil
 }
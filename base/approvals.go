@@ -0,0 +1,58 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GetApproved returns the address approved to transfer a single basename
+// token, or the zero address when there is none.
+func (c *Client) GetApproved(ctx context.Context, tokenId *big.Int) (common.Address, error) {
+	contract := c.NewBasenamesContract()
+
+	outputs, err := c.Call(ctx, contract, "getApproved", tokenId)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return outputs[0].(common.Address), nil
+}
+
+// IsApprovedForAll reports whether operator may manage every basename owned by owner.
+func (c *Client) IsApprovedForAll(ctx context.Context, owner, operator common.Address) (bool, error) {
+	contract := c.NewBasenamesContract()
+
+	outputs, err := c.Call(ctx, contract, "isApprovedForAll", owner, operator)
+	if err != nil {
+		return false, err
+	}
+	return outputs[0].(bool), nil
+}
+
+// Approve approves spender to transfer a single basename token. Approving the
+// zero address clears the approval.
+func (c *Client) Approve(ctx context.Context, spender common.Address, tokenId *big.Int) (*TxResult, error) {
+	contract := c.NewBasenamesContract()
+
+	data, err := contract.ABI.Pack("approve", spender, tokenId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode approve call: %v", err)
+	}
+
+	return c.WriteContract(ctx, contract.Address, data, nil)
+}
+
+// SetApprovalForAll grants or revokes operator's approval over every basename
+// owned by the signer.
+func (c *Client) SetApprovalForAll(ctx context.Context, operator common.Address, approved bool) (*TxResult, error) {
+	contract := c.NewBasenamesContract()
+
+	data, err := contract.ABI.Pack("setApprovalForAll", operator, approved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode setApprovalForAll call: %v", err)
+	}
+
+	return c.WriteContract(ctx, contract.Address, data, nil)
+}
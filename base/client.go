@@ -1,27 +1,79 @@
 package base
 
 import (
+	"math/big"
 	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultGasMarginPercent is added on top of estimated gas limits.
+	DefaultGasMarginPercent = 20
+	// DefaultConfirmations is the number of blocks to wait for a receipt.
+	DefaultConfirmations = 1
+	// DefaultReceiptTimeout bounds how long to wait for a receipt.
+	DefaultReceiptTimeout = 2 * time.Minute
 )
 
 type Client struct {
 	HttpClient http.Client
-	RpcURL     string
-	PrivateKey string
-	Address    string
-}
+	// RpcURLs are tried in order, failing over on transient errors
+	RpcURLs []string
+	// Network is the chain the endpoints are expected to serve
+	Network Network
+	// Address is the signer's address once LoadSigner is called, or the
+	// watched address of read-only commands
+	Address   string
+	TxOptions TxOptions
+	Retry     RetryPolicy
 
-func (c *Client) setHeaders(req *http.Request) {
+	// signerConfig is where LoadSigner takes the signer from
+	signerConfig SignerConfig
+	signer       Signer
+
+	// Each endpoint's connection is dialed on first use and shared by every call
+	mu        sync.Mutex
+	endpoints []*endpoint
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+// TxOptions overrides the gas settings WriteContract derives from the chain.
+type TxOptions struct {
+	// MaxFee is the max fee per gas in wei. When nil it is derived from the
+	// latest base fee.
+	MaxFee *big.Int
+	// PriorityFee is the max priority fee per gas in wei. When nil it is
+	// taken from eth_maxPriorityFeePerGas.
+	PriorityFee *big.Int
+	// GasLimit skips gas estimation when non-zero.
+	GasLimit uint64
+	// GasMarginPercent is the safety margin added to estimated gas limits.
+	GasMarginPercent uint64
+	// Confirmations is the number of blocks, including the inclusion block,
+	// to wait for before a receipt is returned.
+	Confirmations uint64
+	// ReceiptTimeout bounds how long to wait for the receipt.
+	ReceiptTimeout time.Duration
 }
 
-func NewClient(rpcURL, privateKey string, address string) *Client {
+// NewClient creates a client from config. It neither dials the endpoints nor
+// loads the signer.
+func NewClient(config Config) *Client {
 	return &Client{
-		HttpClient: http.Client{},
-		RpcURL:     rpcURL,
-		PrivateKey: privateKey,
-		Address:    address,
+		HttpClient: http.Client{
+			// Keep connections to the RPC endpoint alive across calls
+			Transport: &http.Transport{
+				Proxy:               http.ProxyFromEnvironment,
+				MaxIdleConns:        100,
+				MaxIdleConnsPerHost: 100,
+				IdleConnTimeout:     90 * time.Second,
+			},
+		},
+		RpcURLs:      config.RpcURLs,
+		Network:      config.Network,
+		TxOptions:    config.TxOptions,
+		Retry:        DefaultRetryPolicy(),
+		signerConfig: config.Signer,
+		endpoints:    newEndpoints(config.RpcURLs),
 	}
 }
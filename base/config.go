@@ -0,0 +1,75 @@
+package base
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+// Signer types accepted by SignerConfig.Type.
+const (
+	// SignerPrivateKey signs with a hex private key from the configuration.
+	SignerPrivateKey = "private_key"
+	// SignerKeystore signs with an account of an encrypted V3 JSON keystore.
+	SignerKeystore = "keystore"
+	// SignerMnemonic signs with a key derived from a BIP-39 mnemonic.
+	SignerMnemonic = "mnemonic"
+	// SignerRemote sends transactions to an external signer over JSON-RPC.
+	SignerRemote = "remote"
+)
+
+// SignerTypes returns the accepted signer types.
+func SignerTypes() []string {
+	return []string{SignerPrivateKey, SignerKeystore, SignerMnemonic, SignerRemote}
+}
+
+// Config is the effective configuration of the client. The CLI merges it from
+// flags, environment variables and the config file.
+type Config struct {
+	// RpcURLs are tried in order, failing over on transient errors
+	RpcURLs []string
+	// Network is the chain the endpoints are expected to serve and its
+	// contract addresses
+	Network   Network
+	Signer    SignerConfig
+	TxOptions TxOptions
+}
+
+// SignerConfig selects where the signing key comes from.
+type SignerConfig struct {
+	// Type is one of SignerTypes
+	Type       string
+	PrivateKey string
+	// KeystoreDir holds the keystore files. KeystoreAccount picks an account
+	// by address and may be empty when the keystore has a single account.
+	KeystoreDir     string
+	KeystoreAccount string
+	// Password returns the keystore password. It is only called when a
+	// transaction is signed.
+	Password func(ctx context.Context) (string, error)
+	// Mnemonic is a BIP-39 phrase and MnemonicPath the BIP-32 derivation
+	// path of the account
+	Mnemonic     string
+	MnemonicPath string
+	// RemoteURL is the JSON-RPC endpoint of an external signer such as
+	// Clef. RemoteAccount may be empty when it manages a single account.
+	RemoteURL     string
+	RemoteAccount string
+}
+
+// DefaultConfig returns the configuration for Base mainnet with no RPC URL
+// and no signer.
+func DefaultConfig() Config {
+	return Config{
+		Network: BaseMainnet,
+		Signer: SignerConfig{
+			Type:         SignerPrivateKey,
+			MnemonicPath: accounts.DefaultBaseDerivationPath.String(),
+		},
+		TxOptions: TxOptions{
+			GasMarginPercent: DefaultGasMarginPercent,
+			Confirmations:    DefaultConfirmations,
+			ReceiptTimeout:   DefaultReceiptTimeout,
+		},
+	}
+}
@@ -0,0 +1,400 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	DefaultMaxAttempts      = 4
+	DefaultBaseDelay        = 250 * time.Millisecond
+	DefaultMaxDelay         = 5 * time.Second
+	DefaultFailureThreshold = 3
+	DefaultCooldown         = 30 * time.Second
+
+	// maxHeadLag is how far behind the best head an endpoint may be before
+	// the health check reports it as unhealthy.
+	maxHeadLag = 10
+)
+
+// RetryPolicy controls how RPC calls are retried across endpoints.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per call, across all endpoints.
+	MaxAttempts int
+	// BaseDelay and MaxDelay bound the exponential backoff between attempts.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// FailureThreshold consecutive transient failures open an endpoint's
+	// circuit breaker for Cooldown.
+	FailureThreshold int
+	Cooldown         time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used by NewClient.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:      DefaultMaxAttempts,
+		BaseDelay:        DefaultBaseDelay,
+		MaxDelay:         DefaultMaxDelay,
+		FailureThreshold: DefaultFailureThreshold,
+		Cooldown:         DefaultCooldown,
+	}
+}
+
+// endpoint is a single RPC URL with its lazily dialed connection and circuit
+// breaker state. All fields are guarded by Client.mu.
+type endpoint struct {
+	url       string
+	rpcClient *rpc.Client
+	ethClient *ethclient.Client
+	failures  int
+	openUntil time.Time
+	// verified is set once the endpoint is known to serve the client's network
+	verified bool
+}
+
+// EndpointHealth is the result of probing a single RPC endpoint.
+type EndpointHealth struct {
+	URL         string
+	ChainID     uint64
+	BlockNumber uint64
+	Latency     time.Duration
+	Healthy     bool
+	Reason      string
+}
+
+// ParseRpcURLs splits a comma-separated list of RPC URLs.
+func ParseRpcURLs(value string) []string {
+	var urls []string
+	for _, url := range strings.Split(value, ",") {
+		url = strings.TrimSpace(url)
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+func newEndpoints(urls []string) []*endpoint {
+	endpoints := make([]*endpoint, 0, len(urls))
+	for _, url := range urls {
+		endpoints = append(endpoints, &endpoint{url: url})
+	}
+	return endpoints
+}
+
+// withClient runs fn against the first available endpoint, retrying transient
+// failures with exponential backoff and jitter and failing over to the next
+// endpoint in order. Each endpoint is verified to serve the client's network
+// before its first use. Non-transient errors are returned immediately.
+// Returned errors are tagged with their ErrorKind.
+func (c *Client) withClient(ctx context.Context, fn func(client *ethclient.Client) error) error {
+	if len(c.endpoints) == 0 {
+		return Errorf(KindInvalidInput, "no RPC URL configured, set %s or rpc_url in the config file", BASENAMES_RPC_URL)
+	}
+
+	attempts := c.Retry.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	tried := make(map[*endpoint]bool)
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := c.backoff(ctx, attempt); err != nil {
+				return rpcError(fmt.Errorf("%w (last error: %v)", err, lastErr))
+			}
+		}
+
+		ep := c.pickEndpoint(tried)
+		tried[ep] = true
+
+		client, err := c.dial(ctx, ep)
+		if err == nil {
+			err = c.verify(ctx, ep, client)
+		}
+		if err == nil {
+			err = fn(client)
+		}
+		if err == nil {
+			c.recordSuccess(ep)
+			return nil
+		}
+		if ctx.Err() != nil || !isTransient(err) {
+			return rpcError(err)
+		}
+
+		c.recordFailure(ep)
+		lastErr = fmt.Errorf("%s: %w", ep.url, err)
+	}
+
+	return rpcError(lastErr)
+}
+
+// pickEndpoint returns the first endpoint in order that has not been tried in
+// this call and whose breaker is closed. Once every endpoint has been tried a
+// new round starts. If every breaker is open the one closing soonest is used.
+func (c *Client) pickEndpoint(tried map[*endpoint]bool) *endpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for round := 0; round < 2; round++ {
+		for _, ep := range c.endpoints {
+			if !tried[ep] && !now.Before(ep.openUntil) {
+				return ep
+			}
+		}
+		for ep := range tried {
+			delete(tried, ep)
+		}
+	}
+
+	soonest := c.endpoints[0]
+	for _, ep := range c.endpoints[1:] {
+		if ep.openUntil.Before(soonest.openUntil) {
+			soonest = ep
+		}
+	}
+	return soonest
+}
+
+func (c *Client) dial(ctx context.Context, ep *endpoint) (*ethclient.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ep.ethClient != nil {
+		return ep.ethClient, nil
+	}
+
+	rpcClient, err := rpc.DialOptions(ctx, ep.url, rpc.WithHTTPClient(&c.HttpClient))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the Ethereum client: %w", err)
+	}
+
+	ep.rpcClient = rpcClient
+	ep.ethClient = ethclient.NewClient(rpcClient)
+	return ep.ethClient, nil
+}
+
+// verify checks that the endpoint serves the chain of the client's network and
+// that the registrar is deployed there, so a misconfigured RPC URL can never
+// be used to sign or send a transaction for another chain. A mismatch is
+// returned as KindInvalidInput and stops the call instead of failing over.
+func (c *Client) verify(ctx context.Context, ep *endpoint, client *ethclient.Client) error {
+	c.mu.Lock()
+	verified := ep.verified
+	c.mu.Unlock()
+	if verified {
+		return nil
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	if !chainID.IsUint64() || chainID.Uint64() != c.Network.ChainID {
+		return Errorf(KindInvalidInput, "RPC endpoint %s serves chain ID %s, but network %s is chain ID %d; check the RPC URL or select the matching --network",
+			ep.url, chainID, c.Network.Name, c.Network.ChainID)
+	}
+
+	registrar := c.Network.Contracts.Registrar
+	code, err := client.CodeAt(ctx, registrar, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get registrar code: %w", err)
+	}
+	if len(code) == 0 {
+		return Errorf(KindInvalidInput, "no contract deployed at the registrar address %s on chain ID %d (%s); check the contracts of network %s",
+			registrar.Hex(), c.Network.ChainID, ep.url, c.Network.Name)
+	}
+
+	c.mu.Lock()
+	ep.verified = true
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *Client) recordSuccess(ep *endpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ep.failures = 0
+	ep.openUntil = time.Time{}
+}
+
+func (c *Client) recordFailure(ep *endpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ep.failures++
+	if c.Retry.FailureThreshold > 0 && ep.failures >= c.Retry.FailureThreshold {
+		ep.openUntil = time.Now().Add(c.Retry.Cooldown)
+		ep.failures = 0
+	}
+}
+
+// backoff sleeps for an exponentially growing, fully jittered delay.
+func (c *Client) backoff(ctx context.Context, attempt int) error {
+	delay := c.Retry.BaseDelay << (attempt - 1)
+	if delay <= 0 || (c.Retry.MaxDelay > 0 && delay > c.Retry.MaxDelay) {
+		delay = c.Retry.MaxDelay
+	}
+	if delay > 0 {
+		delay = time.Duration(rand.Int63n(int64(delay)) + 1)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isTransient reports whether err is worth retrying on another attempt:
+// rate limiting, server errors and dropped connections.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32005 {
+		// Limit exceeded
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "rate limit") || strings.Contains(message, "too many requests")
+}
+
+// HealthCheck probes every endpoint for its chain ID and head block. Endpoints
+// that fail, serve another chain than the client's network, disagree with the
+// majority chain ID or lag the best head by more than a few blocks are
+// reported as unhealthy.
+func (c *Client) HealthCheck(ctx context.Context) []EndpointHealth {
+	results := make([]EndpointHealth, len(c.endpoints))
+
+	var wg sync.WaitGroup
+	for i, ep := range c.endpoints {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+			results[i] = c.probe(ctx, ep)
+		}(i, ep)
+	}
+	wg.Wait()
+
+	chainVotes := make(map[uint64]int)
+	var bestHead uint64
+	for _, result := range results {
+		if result.Reason != "" {
+			continue
+		}
+		chainVotes[result.ChainID]++
+		if result.BlockNumber > bestHead {
+			bestHead = result.BlockNumber
+		}
+	}
+
+	chainIDs := make([]uint64, 0, len(chainVotes))
+	for chainID := range chainVotes {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Slice(chainIDs, func(i, j int) bool { return chainVotes[chainIDs[i]] > chainVotes[chainIDs[j]] })
+
+	for i := range results {
+		result := &results[i]
+		switch {
+		case result.Reason != "":
+		case c.Network.ChainID != 0 && result.ChainID != c.Network.ChainID:
+			result.Reason = fmt.Sprintf("chain ID %d differs from %d of network %s", result.ChainID, c.Network.ChainID, c.Network.Name)
+		case len(chainIDs) > 1 && result.ChainID != chainIDs[0]:
+			result.Reason = fmt.Sprintf("chain ID %d differs from %d reported by the other endpoints", result.ChainID, chainIDs[0])
+		case bestHead-result.BlockNumber > maxHeadLag:
+			result.Reason = fmt.Sprintf("head block is %d blocks behind", bestHead-result.BlockNumber)
+		default:
+			result.Healthy = true
+		}
+	}
+
+	return results
+}
+
+func (c *Client) probe(ctx context.Context, ep *endpoint) EndpointHealth {
+	result := EndpointHealth{URL: ep.url}
+	start := time.Now()
+
+	client, err := c.dial(ctx, ep)
+	if err != nil {
+		result.Reason = err.Error()
+		return result
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		result.Reason = fmt.Sprintf("failed to get chain ID: %v", err)
+		return result
+	}
+	blockNumber, err := client.BlockNumber(ctx)
+	if err != nil {
+		result.Reason = fmt.Sprintf("failed to get block number: %v", err)
+		return result
+	}
+
+	result.ChainID = chainID.Uint64()
+	result.BlockNumber = blockNumber
+	result.Latency = time.Since(start)
+	return result
+}
+
+// Close releases every RPC connection, including the connection of a remote
+// signer. Endpoints are redialed on next use.
+func (c *Client) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, ep := range c.endpoints {
+		if ep.rpcClient != nil {
+			ep.rpcClient.Close()
+		}
+		ep.rpcClient = nil
+		ep.ethClient = nil
+	}
+	if closer, ok := c.signer.(interface{ Close() }); ok {
+		closer.Close()
+	}
+	c.HttpClient.CloseIdleConnections()
+}
@@ -0,0 +1,104 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrorKind classifies the errors returned by the client so callers can react
+// to the category of a failure rather than its message.
+type ErrorKind int
+
+const (
+	KindUnknown ErrorKind = iota
+	KindInvalidInput
+	KindRPC
+	KindReverted
+	KindNotFound
+	KindInsufficientFunds
+	// KindUnexpectedState is a mined transaction whose outcome on chain is
+	// not the expected one
+	KindUnexpectedState
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case KindInvalidInput:
+		return "invalid_input"
+	case KindRPC:
+		return "rpc"
+	case KindReverted:
+		return "reverted"
+	case KindNotFound:
+		return "not_found"
+	case KindInsufficientFunds:
+		return "insufficient_funds"
+	case KindUnexpectedState:
+		return "unexpected_state"
+	}
+	return "unknown"
+}
+
+// Error is an error tagged with its ErrorKind. The message is the wrapped
+// error's message.
+type Error struct {
+	Kind ErrorKind
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errorf formats an error tagged with kind.
+func Errorf(kind ErrorKind, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// KindOf returns the kind of err. Reverts and missing primary names are
+// recognized directly; otherwise the outermost tagged error decides.
+func KindOf(err error) ErrorKind {
+	if err == nil {
+		return KindUnknown
+	}
+
+	var revert *RevertError
+	if errors.As(err, &revert) || errors.Is(err, ErrTransactionReverted) {
+		return KindReverted
+	}
+	if errors.Is(err, ErrNoPrimaryName) {
+		return KindNotFound
+	}
+
+	var tagged *Error
+	if errors.As(err, &tagged) {
+		return tagged.Kind
+	}
+	return KindUnknown
+}
+
+// rpcError tags an error returned while talking to a node. Errors that are
+// already tagged keep their kind.
+func rpcError(err error) error {
+	var tagged *Error
+	if err == nil || errors.As(err, &tagged) {
+		return err
+	}
+
+	message := strings.ToLower(err.Error())
+	var dataErr rpc.DataError
+	switch {
+	case strings.Contains(message, "insufficient funds"):
+		return &Error{Kind: KindInsufficientFunds, Err: err}
+	case errors.As(err, &dataErr), strings.Contains(message, "execution reverted"):
+		return &Error{Kind: KindReverted, Err: err}
+	}
+	return &Error{Kind: KindRPC, Err: err}
+}
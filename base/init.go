@@ -1,9 +1,10 @@
 package base
 
 import (
-	"fmt"
-	"log"
-	"os"
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 var BaseClient *Client
@@ -11,48 +12,52 @@ var BaseClient *Client
 const (
 	BASENAMES_RPC_URL     = "BASENAMES_RPC_URL"
 	BASENAMES_PRIVATE_KEY = "BASENAMES_PRIVATE_KEY"
+	BASENAMES_MNEMONIC    = "BASENAMES_MNEMONIC"
+	BASENAMES_REMOTE_URL  = "BASENAMES_REMOTE_URL"
 )
 
-func ReadEnvCredentials() (*Credentials, error) {
-	rpcURL := os.Getenv(BASENAMES_RPC_URL)
-	privateKey := os.Getenv(BASENAMES_PRIVATE_KEY)
-
-	if rpcURL == "" {
-		return nil, fmt.Errorf("%s not set as an environment variable", BASENAMES_RPC_URL)
-	}
-
-	if privateKey == "" {
-		return nil, fmt.Errorf("%s not set as an environment variable", BASENAMES_PRIVATE_KEY)
-	}
+// InitClient creates BaseClient from config. It neither dials the endpoints
+// nor loads the signer, so read-only commands work without a private key.
+func InitClient(config Config) {
+	BaseClient = NewClient(config)
+}
 
-	// Remove "0x" prefix from private key if present
-	if len(privateKey) > 2 && privateKey[:2] == "0x" {
-		privateKey = privateKey[2:]
-	}
+func IsClientInitialized() bool {
+	return BaseClient != nil
+}
 
-	address, err := GetAddressFromPrivateKey(privateKey)
+// LoadSigner creates the configured signer and signs with it from then on.
+// Keys that are encrypted or held by a remote signer are only used when a
+// transaction is signed.
+func (c *Client) LoadSigner(ctx context.Context) error {
+	signer, err := NewSigner(ctx, c.signerConfig, &c.HttpClient)
 	if err != nil {
-		return nil, fmt.Errorf("error deriving address from private key: %v", err)
+		return err
 	}
+	c.SetSigner(signer)
+	return nil
+}
 
-	return &Credentials{
-		RpcUrl:     rpcURL,
-		PrivateKey: privateKey,
-		Address:    address,
-	}, nil
+// SetSigner signs with signer from then on. The client's address becomes the
+// signer's address.
+func (c *Client) SetSigner(signer Signer) {
+	c.signer = signer
+	c.Address = signer.Address().Hex()
 }
 
-func InitClient() error {
-	creds, err := ReadEnvCredentials()
-	if err != nil {
-		log.Fatalf("error reading environmental variables: %s", err)
-	}
+// signTransaction signs tx for its chain ID with the loaded signer.
+func (c *Client) signTransaction(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return c.signer.SignTx(ctx, tx, tx.ChainId())
+}
 
-	BaseClient = NewClient(creds.RpcUrl, creds.PrivateKey, creds.Address)
-	fmt.Printf("Client initialized! \nHello: %s\n", creds.Address)
-	return nil
+// WatchAddress sets the client's address without a signer, for read-only
+// queries about an account.
+func (c *Client) WatchAddress(address common.Address) {
+	c.signer = nil
+	c.Address = address.Hex()
 }
 
-func IsClientInitialized() bool {
-	return BaseClient != nil
+// CanSign reports whether a signer has been loaded.
+func (c *Client) CanSign() bool {
+	return c.signer != nil
 }
@@ -0,0 +1,89 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// OpenKeystore opens the V3 JSON keystore in dir. The directory is created
+// when the first account is added.
+func OpenKeystore(dir string) *keystore.KeyStore {
+	return keystore.NewKeyStore(dir, keystore.StandardScryptN, keystore.StandardScryptP)
+}
+
+// KeystoreAccount returns the account with the given address, or the only
+// account of the keystore when address is empty.
+func KeystoreAccount(ks *keystore.KeyStore, address string) (accounts.Account, error) {
+	if address != "" {
+		if !common.IsHexAddress(address) {
+			return accounts.Account{}, Errorf(KindInvalidInput, "invalid keystore account %q", address)
+		}
+		account, err := ks.Find(accounts.Account{Address: common.HexToAddress(address)})
+		if err != nil {
+			return accounts.Account{}, Errorf(KindNotFound, "no account %s in the keystore", common.HexToAddress(address).Hex())
+		}
+		return account, nil
+	}
+
+	all := ks.Accounts()
+	switch len(all) {
+	case 0:
+		return accounts.Account{}, Errorf(KindNotFound, "the keystore has no accounts")
+	case 1:
+		return all[0], nil
+	}
+	return accounts.Account{}, Errorf(KindInvalidInput, "the keystore has %d accounts, set keystore.account to choose one", len(all))
+}
+
+// KeystoreSigner signs with an account of a V3 JSON keystore. The key stays
+// encrypted on disk and is decrypted for each signature only.
+type KeystoreSigner struct {
+	ks       *keystore.KeyStore
+	account  accounts.Account
+	password func(ctx context.Context) (string, error)
+}
+
+// NewKeystoreSigner locates the account in the keystore in dir, see
+// KeystoreAccount. password is called each time a transaction is signed.
+func NewKeystoreSigner(dir, address string, password func(ctx context.Context) (string, error)) (*KeystoreSigner, error) {
+	ks := OpenKeystore(dir)
+	account, err := KeystoreAccount(ks, address)
+	if err != nil {
+		return nil, fmt.Errorf("keystore %s: %w", dir, err)
+	}
+	if password == nil {
+		return nil, Errorf(KindInvalidInput, "no password source for keystore %s", dir)
+	}
+	return &KeystoreSigner{ks: ks, account: account, password: password}, nil
+}
+
+func (s *KeystoreSigner) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *KeystoreSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	password, err := s.password(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := s.ks.SignTxWithPassphrase(s.account, password, tx, chainID)
+	if err != nil {
+		return nil, keystoreError(err)
+	}
+	return signedTx, nil
+}
+
+// keystoreError reports a wrong password as invalid input.
+func keystoreError(err error) error {
+	if errors.Is(err, keystore.ErrDecrypt) {
+		return Errorf(KindInvalidInput, "failed to decrypt the keystore key: wrong password")
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+package base
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// NewMnemonicSigner derives the key at the BIP-32 derivation path from a
+// BIP-39 mnemonic, for example m/44'/60'/0'/0/0 for the first account of
+// most wallets.
+func NewMnemonicSigner(mnemonic, path string) (*KeySigner, error) {
+	if mnemonic == "" {
+		return nil, Errorf(KindInvalidInput, "no mnemonic configured, set %s or mnemonic in the config file", BASENAMES_MNEMONIC)
+	}
+
+	// Normalize the whitespace between words
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, Errorf(KindInvalidInput, "invalid mnemonic: %v", err)
+	}
+
+	derivationPath, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, Errorf(KindInvalidInput, "invalid derivation path %q: %v", path, err)
+	}
+
+	key, err := deriveKey(seed, derivationPath)
+	if err != nil {
+		return nil, Errorf(KindInvalidInput, "failed to derive the key at %s: %v", path, err)
+	}
+	return newKeySigner(key), nil
+}
+
+// deriveKey derives the BIP-32 private key at path from a seed.
+func deriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	key, chainCode := hmacSHA512([]byte("Bitcoin seed"), seed)
+	if _, err := crypto.ToECDSA(key); err != nil {
+		return nil, fmt.Errorf("invalid master key: %w", err)
+	}
+
+	n := crypto.S256().Params().N
+	for _, index := range path {
+		// Hardened children are derived from the private key, normal children
+		// from the compressed public key
+		var data []byte
+		if index >= 0x80000000 {
+			data = append([]byte{0}, key...)
+		} else {
+			parent, err := crypto.ToECDSA(key)
+			if err != nil {
+				return nil, err
+			}
+			data = crypto.CompressPubkey(&parent.PublicKey)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		tweak, childChainCode := hmacSHA512(chainCode, data)
+		child := new(big.Int).SetBytes(tweak)
+		if child.Cmp(n) >= 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		child.Add(child, new(big.Int).SetBytes(key)).Mod(child, n)
+		if child.Sign() == 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		key, chainCode = math.PaddedBigBytes(child, 32), childChainCode
+	}
+	return crypto.ToECDSA(key)
+}
+
+// hmacSHA512 returns the two halves of HMAC-SHA512(key, data).
+func hmacSHA512(key, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
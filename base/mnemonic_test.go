@@ -0,0 +1,54 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// testMnemonic is the default development mnemonic of Hardhat and Anvil.
+const testMnemonic = "test test test test test test test test test test test junk"
+
+func TestNewMnemonicSigner(t *testing.T) {
+	tests := []struct {
+		path    string
+		address string
+	}{
+		{"m/44'/60'/0'/0/0", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+		{"m/44'/60'/0'/0/1", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+		{"m/44'/60'/0'/0/2", "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			signer, err := NewMnemonicSigner(testMnemonic, test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if address := signer.Address(); address != common.HexToAddress(test.address) {
+				t.Errorf("address = %s, want %s", address.Hex(), test.address)
+			}
+		})
+	}
+}
+
+func TestNewMnemonicSignerInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		path     string
+	}{
+		{"empty mnemonic", "", "m/44'/60'/0'/0/0"},
+		{"bad checksum", "test test test test test test test test test test test test", "m/44'/60'/0'/0/0"},
+		{"bad path", testMnemonic, "m/44'/sixty"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewMnemonicSigner(test.mnemonic, test.path)
+			if KindOf(err) != KindInvalidInput {
+				t.Errorf("error = %v, want an invalid input error", err)
+			}
+		})
+	}
+}
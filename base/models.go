@@ -1,13 +1,26 @@
 package base
 
-type Credentials struct {
-	RpcUrl     string
-	PrivateKey string
-	Address    string
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxResult is the outcome of a mined transaction.
+type TxResult struct {
+	Hash              common.Hash
+	Success           bool
+	BlockNumber       *big.Int
+	Confirmations     uint64
+	GasUsed           uint64
+	EffectiveGasPrice *big.Int
+	Logs              []DecodedLog
 }
 
-var GetBlockResponse struct {
-	ID      int    `json:"id"`
-	JSONRPC string `json:"jsonrpc"`
-	Result  string `json:"result"`
+// DecodedLog is a receipt log decoded against the known contract ABIs. Event
+// is empty when the log did not match any of them.
+type DecodedLog struct {
+	Address common.Address
+	Event   string
+	Args    map[string]interface{}
 }
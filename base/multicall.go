@@ -0,0 +1,186 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// multicallBatchSize caps the number of calls aggregated into one eth_call.
+const multicallBatchSize = 100
+
+// BatchCall is a single read-only call in a Multicall3 batch.
+type BatchCall struct {
+	Contract *Contract
+	Method   string
+	Args     []interface{}
+}
+
+// BatchResult is the decoded outcome of a BatchCall. Err is set when the call
+// reverted or its result could not be decoded; the rest of the batch is
+// unaffected.
+type BatchResult struct {
+	Outputs []interface{}
+	Err     error
+}
+
+// call3 and result3 mirror Multicall3's Call3 and Result structs.
+type call3 struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+type result3 struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// Multicall executes read-only calls through the Multicall3 contract,
+// aggregating up to multicallBatchSize calls per eth_call. Individual calls
+// may fail without failing the batch.
+func (c *Client) Multicall(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	results := make([]BatchResult, len(calls))
+	multicall := c.NewMulticall3Contract()
+
+	for start := 0; start < len(calls); start += multicallBatchSize {
+		end := start + multicallBatchSize
+		if end > len(calls) {
+			end = len(calls)
+		}
+
+		batch := make([]call3, 0, end-start)
+		for _, call := range calls[start:end] {
+			data, err := call.Contract.ABI.Pack(call.Method, call.Args...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode %s call: %v", call.Method, err)
+			}
+			batch = append(batch, call3{Target: call.Contract.Address, AllowFailure: true, CallData: data})
+		}
+
+		outputs, err := c.Call(ctx, multicall, "aggregate3", batch)
+		if err != nil {
+			return nil, err
+		}
+		returned := *abi.ConvertType(outputs[0], new([]result3)).(*[]result3)
+		if len(returned) != len(batch) {
+			return nil, fmt.Errorf("multicall returned %d results for %d calls", len(returned), len(batch))
+		}
+
+		for i, result := range returned {
+			call := calls[start+i]
+			if !result.Success {
+				results[start+i].Err = decodeRevertData(result.ReturnData)
+				continue
+			}
+
+			decoded, err := call.Contract.ABI.Unpack(call.Method, result.ReturnData)
+			if err != nil {
+				results[start+i].Err = fmt.Errorf("failed to decode %s result: %v", call.Method, err)
+				continue
+			}
+			results[start+i].Outputs = decoded
+		}
+	}
+
+	return results, nil
+}
+
+// NameInfo is the registrar and registry state of a basename.
+type NameInfo struct {
+	Basename  *Basename
+	Available bool
+	Expires   time.Time
+	// Owner is the zero address when the token does not exist or has expired.
+	Owner common.Address
+	// Resolver is only looked up for basenames with a known label.
+	Resolver common.Address
+	Err      error
+}
+
+// Name statuses reported by NameInfo.Status.
+const (
+	StatusAvailable = "available"
+	StatusTaken     = "taken"
+	StatusGrace     = "grace"
+)
+
+// Status reports whether the name is available, registered, or expired but
+// still in the registrar's grace period, during which only the previous
+// owner can renew it.
+func (i NameInfo) Status(now time.Time) string {
+	switch {
+	case i.Available:
+		return StatusAvailable
+	case i.Expires.Before(now):
+		return StatusGrace
+	}
+	return StatusTaken
+}
+
+// LookupNames batches isAvailable, nameExpires, ownerOf and the registry
+// resolver lookup for every basename into as few eth_calls as possible.
+func (c *Client) LookupNames(ctx context.Context, basenames []*Basename) ([]NameInfo, error) {
+	registrar := c.NewBasenamesContract()
+	registry := c.NewRegistryContract()
+
+	const (
+		isAvailable = iota
+		nameExpires
+		ownerOf
+		resolver
+		callsPerName
+	)
+
+	calls := make([]BatchCall, 0, len(basenames)*callsPerName)
+	for _, basename := range basenames {
+		// Names without a label still get a placeholder resolver call to keep indexes aligned
+		var node common.Hash
+		if basename.Label != "" {
+			node = Namehash(basename.Name())
+		}
+		calls = append(calls,
+			BatchCall{Contract: registrar, Method: "isAvailable", Args: []interface{}{basename.TokenId}},
+			BatchCall{Contract: registrar, Method: "nameExpires", Args: []interface{}{basename.TokenId}},
+			BatchCall{Contract: registrar, Method: "ownerOf", Args: []interface{}{basename.TokenId}},
+			BatchCall{Contract: registry, Method: "resolver", Args: []interface{}{node}},
+		)
+	}
+
+	results, err := c.Multicall(ctx, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]NameInfo, len(basenames))
+	for i, basename := range basenames {
+		r := results[i*callsPerName : (i+1)*callsPerName]
+		info := NameInfo{Basename: basename}
+
+		switch {
+		case r[isAvailable].Err != nil:
+			info.Err = r[isAvailable].Err
+		case r[nameExpires].Err != nil:
+			info.Err = r[nameExpires].Err
+		default:
+			info.Available = r[isAvailable].Outputs[0].(bool)
+			info.Expires = time.Unix(r[nameExpires].Outputs[0].(*big.Int).Int64(), 0)
+		}
+
+		// ownerOf reverts for tokens that do not exist or have expired
+		if r[ownerOf].Err == nil {
+			info.Owner = r[ownerOf].Outputs[0].(common.Address)
+		}
+		if basename.Label != "" && r[resolver].Err == nil {
+			info.Resolver = r[resolver].Outputs[0].(common.Address)
+		}
+
+		infos[i] = info
+	}
+
+	return infos, nil
+}
@@ -0,0 +1,157 @@
+package base
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Basename identifies a basename token on the registrar.
+type Basename struct {
+	// Label is the normalized label without the parent domain. It is empty
+	// when the basename was given as a raw tokenId or labelhash.
+	Label string
+	// Domain is the parent domain the name is registered under, such as
+	// base.eth
+	Domain  string
+	TokenId *big.Int
+}
+
+// ParseBasename parses a basename under the domain of the client's network.
+func (c *Client) ParseBasename(input string) (*Basename, error) {
+	return ParseBasename(input, c.Network.Domain)
+}
+
+// ParseBasename accepts a name ("alice" or "alice.base.eth"), a hex labelhash
+// ("0x" followed by 64 hex characters) or a decimal tokenId and returns the
+// matching Basename under domain. Inputs made only of digits are treated as
+// tokenIds; add the domain suffix to look up a numeric name.
+func ParseBasename(input string, domain string) (*Basename, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, Errorf(KindInvalidInput, "empty basename")
+	}
+
+	if isDecimal(input) {
+		tokenId, success := new(big.Int).SetString(input, 10)
+		if !success {
+			return nil, Errorf(KindInvalidInput, "invalid tokenId %q", input)
+		}
+		return &Basename{Domain: domain, TokenId: tokenId}, nil
+	}
+
+	if len(input) == 66 && (strings.HasPrefix(input, "0x") || strings.HasPrefix(input, "0X")) {
+		tokenId, success := new(big.Int).SetString(input[2:], 16)
+		if !success {
+			return nil, Errorf(KindInvalidInput, "invalid labelhash %q", input)
+		}
+		return &Basename{Domain: domain, TokenId: tokenId}, nil
+	}
+
+	label, err := NormalizeLabel(input, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Basename{
+		Label:   label,
+		Domain:  domain,
+		TokenId: LabelHash(label).Big(),
+	}, nil
+}
+
+// NormalizeLabel lowercases a basename, strips the domain suffix and
+// validates that a single label of ASCII letters, digits and hyphens remains.
+// Such labels are already normalized under ENSIP-15. Other characters are
+// rejected rather than normalized differently from ENS clients, which would
+// give a name no one else can reach.
+func NormalizeLabel(name string, domain string) (string, error) {
+	label := strings.ToLower(strings.TrimSpace(name))
+	label = strings.TrimSuffix(label, "."+domain)
+
+	if label == "" {
+		return "", Errorf(KindInvalidInput, "empty basename")
+	}
+	if strings.Contains(label, ".") {
+		return "", Errorf(KindInvalidInput, "invalid basename %q: only names directly under %s are supported", name, domain)
+	}
+	for _, r := range label {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return "", Errorf(KindInvalidInput, "invalid basename %q: only the letters a-z, digits and hyphens are supported", name)
+		}
+	}
+	// ENSIP-15 reserves labels with hyphens as their third and fourth
+	// characters, such as punycode's xn--
+	if len(label) >= 4 && label[2:4] == "--" {
+		return "", Errorf(KindInvalidInput, "invalid basename %q: hyphens in the third and fourth position are not allowed", name)
+	}
+
+	return label, nil
+}
+
+// LabelHash returns the keccak256 hash of a normalized label, which is also
+// the label's tokenId on the registrar.
+func LabelHash(label string) common.Hash {
+	return crypto.Keccak256Hash([]byte(label))
+}
+
+// Namehash computes the ENS namehash of a dot-separated name.
+func Namehash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		labelHash := LabelHash(labels[i])
+		node = crypto.Keccak256Hash(node.Bytes(), labelHash.Bytes())
+	}
+	return node
+}
+
+// Node returns the namehash of the full basename. It requires the label to be
+// known, since a tokenId cannot be reversed into a name.
+func (b *Basename) Node() (common.Hash, error) {
+	if b.Label == "" {
+		return common.Hash{}, Errorf(KindInvalidInput, "token ID %s has no known name; pass the basename instead", b.TokenId)
+	}
+	return Namehash(b.Name()), nil
+}
+
+// Name returns the full basename, or an empty string when only the tokenId
+// is known.
+func (b *Basename) Name() string {
+	if b.Label == "" {
+		return ""
+	}
+	return b.Label + "." + b.Domain
+}
+
+// String returns the full name when known, otherwise the decimal tokenId.
+func (b *Basename) String() string {
+	if b.Label == "" {
+		return b.TokenId.String()
+	}
+	return b.Name()
+}
+
+// Describe returns the basename together with its tokenId for display.
+func (b *Basename) Describe() string {
+	if b.Label == "" {
+		return fmt.Sprintf("token ID %s", b.TokenId)
+	}
+	return fmt.Sprintf("%s (token ID %s)", b.Name(), b.TokenId)
+}
+
+func isDecimal(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package base
+
+import "testing"
+
+func TestNormalizeLabel(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		label string
+	}{
+		{"lowercase", "alice", "alice"},
+		{"uppercase", "ALICE", "alice"},
+		{"domain suffix", "Alice.base.eth", "alice"},
+		{"digits and hyphens", "-al1ce-", "-al1ce-"},
+		{"surrounding whitespace", " alice ", "alice"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			label, err := NormalizeLabel(test.input, BaseMainnet.Domain)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if label != test.label {
+				t.Errorf("label = %q, want %q", label, test.label)
+			}
+		})
+	}
+}
+
+func TestNormalizeLabelInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"subdomain", "a.alice.base.eth"},
+		{"fullwidth", "ａｌｉｃｅ"},
+		{"emoji", "gm🙂"},
+		{"Cyrillic confusable", "аlice"},
+		{"accented", "alicé"},
+		{"underscore", "al_ice"},
+		{"inner whitespace", "al ice"},
+		{"label extension", "xn--lice"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			label, err := NormalizeLabel(test.input, BaseMainnet.Domain)
+			if KindOf(err) != KindInvalidInput {
+				t.Errorf("label = %q, error = %v, want an invalid input error", label, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package base
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Multicall3Address is the canonical Multicall3 deployment, at the same
+// address on every chain.
+const Multicall3Address = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+// Contracts holds the addresses of the contracts the client talks to.
+type Contracts struct {
+	Registrar           common.Address
+	RegistrarController common.Address
+	Registry            common.Address
+	L2Resolver          common.Address
+	ReverseRegistrar    common.Address
+	Multicall3          common.Address
+}
+
+// Network is a chain with a Basenames deployment.
+type Network struct {
+	Name    string
+	ChainID uint64
+	// Domain is the parent domain names are registered under
+	Domain string
+	// ReverseNamespace is the reverse namespace primary names are written
+	// under, <coin type>.reverse for the chain (ENSIP-11 and ENSIP-19)
+	ReverseNamespace string
+	// RpcURLs are used when no RPC URL is configured. The built-in networks
+	// have none.
+	RpcURLs   []string
+	Contracts Contracts
+}
+
+// Built-in networks.
+var (
+	BaseMainnet = Network{
+		Name:             "base",
+		ChainID:          8453,
+		Domain:           "base.eth",
+		ReverseNamespace: "80002105.reverse",
+		Contracts: Contracts{
+			Registrar:           common.HexToAddress("0x03c4738Ee98aE44591e1A4A4F3CaB6641d95DD9a"),
+			RegistrarController: common.HexToAddress("0x4cCb0BB02FCABA27e82a56646E81d8c5bC4119a5"),
+			Registry:            common.HexToAddress("0xB94704422c2a1E396835A571837Aa5AE53285a95"),
+			L2Resolver:          common.HexToAddress("0xC6d566A56A1aFf6508b41f6c90ff131615583BCD"),
+			ReverseRegistrar:    common.HexToAddress("0x79EA96012eEa67A83431F1701B3dFf7e37F9E282"),
+			Multicall3:          common.HexToAddress(Multicall3Address),
+		},
+	}
+	BaseSepolia = Network{
+		Name:             "base-sepolia",
+		ChainID:          84532,
+		Domain:           "basetest.eth",
+		ReverseNamespace: "80014a34.reverse",
+		Contracts: Contracts{
+			Registrar:           common.HexToAddress("0xA0c70ec36c010B55E3C434D6c6EbEEC50c705794"),
+			RegistrarController: common.HexToAddress("0x49aE3cC2e3AA768B1e5654f5D3C6002144A59581"),
+			Registry:            common.HexToAddress("0x1493b2567056c2181630115660963E13A8E32735"),
+			L2Resolver:          common.HexToAddress("0x6533C94869D28fAA8dF77cc63f9e2b2D6Cf77eBA"),
+			ReverseRegistrar:    common.HexToAddress("0xa0A8401ECF248a9375a0a71C4dedc263dA18dCd7"),
+			Multicall3:          common.HexToAddress(Multicall3Address),
+		},
+	}
+)
+
+var networks = map[string]Network{
+	BaseMainnet.Name: BaseMainnet,
+	BaseSepolia.Name: BaseSepolia,
+}
+
+// LookupNetwork returns the built-in network with the given name.
+func LookupNetwork(name string) (Network, bool) {
+	network, ok := networks[name]
+	return network, ok
+}
+
+// NetworkNames returns the names of the built-in networks in order.
+func NetworkNames() []string {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ChainReverseNamespace returns the ENSIP-11 reverse namespace of an EVM
+// chain, the hex coin type 0x80000000 | chainID followed by .reverse.
+func ChainReverseNamespace(chainID uint64) string {
+	return fmt.Sprintf("%x.reverse", 0x80000000|chainID)
+}
+
+// Validate checks that the network has a chain ID, a domain, a reverse
+// namespace and every contract the client calls. The reverse registrar is optional.
+func (n Network) Validate() error {
+	if n.ChainID == 0 {
+		return fmt.Errorf("network %s has no chain ID", n.Name)
+	}
+	if n.Domain == "" {
+		return fmt.Errorf("network %s has no domain", n.Name)
+	}
+	if n.ReverseNamespace == "" {
+		return fmt.Errorf("network %s has no reverse namespace", n.Name)
+	}
+
+	required := []struct {
+		name    string
+		address common.Address
+	}{
+		{"registrar", n.Contracts.Registrar},
+		{"registrar controller", n.Contracts.RegistrarController},
+		{"registry", n.Contracts.Registry},
+		{"L2 resolver", n.Contracts.L2Resolver},
+		{"multicall3", n.Contracts.Multicall3},
+	}
+	for _, contract := range required {
+		if contract.address == (common.Address{}) {
+			return fmt.Errorf("network %s has no %s address", n.Name, contract.name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const receiptPollInterval = 2 * time.Second
+
+// ErrTransactionReverted is returned alongside the TxResult of a mined
+// transaction whose status is failed.
+var ErrTransactionReverted = errors.New("transaction reverted")
+
+// WaitForReceipt polls for a transaction's receipt until it has the configured
+// number of confirmations or the receipt timeout expires.
+func (c *Client) WaitForReceipt(ctx context.Context, hash common.Hash) (*TxResult, error) {
+	if c.TxOptions.ReceiptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.TxOptions.ReceiptTimeout)
+		defer cancel()
+	}
+
+	confirmations := c.TxOptions.Confirmations
+	if confirmations == 0 {
+		confirmations = 1
+	}
+
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		var receipt *types.Receipt
+		var head uint64
+		err := c.withClient(ctx, func(client *ethclient.Client) error {
+			var err error
+			receipt, err = client.TransactionReceipt(ctx, hash)
+			if err != nil {
+				return err
+			}
+			head, err = client.BlockNumber(ctx)
+			return err
+		})
+		if err == nil {
+			mined := receipt.BlockNumber.Uint64()
+			if head+1 >= mined+confirmations {
+				return newTxResult(receipt, head+1-mined)
+			}
+		} else if !errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("failed to get receipt for %s: %w", hash.Hex(), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, Errorf(KindRPC, "stopped waiting for transaction %s: %w", hash.Hex(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func newTxResult(receipt *types.Receipt, confirmations uint64) (*TxResult, error) {
+	result := &TxResult{
+		Hash:              receipt.TxHash,
+		Success:           receipt.Status == types.ReceiptStatusSuccessful,
+		BlockNumber:       receipt.BlockNumber,
+		Confirmations:     confirmations,
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: receipt.EffectiveGasPrice,
+		Logs:              make([]DecodedLog, 0, len(receipt.Logs)),
+	}
+
+	for _, log := range receipt.Logs {
+		result.Logs = append(result.Logs, DecodeLog(log))
+	}
+
+	if !result.Success {
+		return result, fmt.Errorf("%w: %s", ErrTransactionReverted, receipt.TxHash.Hex())
+	}
+	return result, nil
+}
+
+// DecodeLog decodes a receipt log using the first known ABI with a matching
+// event signature.
+func DecodeLog(log *types.Log) DecodedLog {
+	decoded := DecodedLog{Address: log.Address}
+	if len(log.Topics) == 0 {
+		return decoded
+	}
+
+	for _, contractABI := range knownABIs() {
+		event, err := contractABI.EventByID(log.Topics[0])
+		if err != nil {
+			continue
+		}
+
+		var indexed abi.Arguments
+		for _, input := range event.Inputs {
+			if input.Indexed {
+				indexed = append(indexed, input)
+			}
+		}
+		if len(indexed) != len(log.Topics)-1 {
+			// Same signature with a different indexing, e.g. ERC-20 vs ERC-721 Transfer
+			continue
+		}
+
+		args := make(map[string]interface{})
+		if err := event.Inputs.UnpackIntoMap(args, log.Data); err != nil {
+			continue
+		}
+		if err := abi.ParseTopicsIntoMap(args, indexed, log.Topics[1:]); err != nil {
+			continue
+		}
+
+		decoded.Event = event.Name
+		decoded.Args = args
+		return decoded
+	}
+
+	return decoded
+}
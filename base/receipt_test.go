@@ -0,0 +1,66 @@
+package base
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestDecodeLog(t *testing.T) {
+	transfer := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	from := common.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23")
+	to := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+	t.Run("ERC-721 transfer", func(t *testing.T) {
+		// Transfer(address indexed from, address indexed to, uint256 indexed id)
+		decoded := DecodeLog(&types.Log{
+			Address: BaseMainnet.Contracts.Registrar,
+			Topics: []common.Hash{
+				transfer,
+				common.BytesToHash(from.Bytes()),
+				common.BytesToHash(to.Bytes()),
+				common.BigToHash(big.NewInt(123)),
+			},
+		})
+
+		if decoded.Event != "Transfer" {
+			t.Fatalf("event = %q, want Transfer", decoded.Event)
+		}
+		if decoded.Args["from"] != from {
+			t.Errorf("from = %v, want %s", decoded.Args["from"], from.Hex())
+		}
+		if decoded.Args["to"] != to {
+			t.Errorf("to = %v, want %s", decoded.Args["to"], to.Hex())
+		}
+		if tokenId, ok := decoded.Args["id"].(*big.Int); !ok || tokenId.Int64() != 123 {
+			t.Errorf("id = %v, want 123", decoded.Args["id"])
+		}
+	})
+
+	t.Run("ERC-20 transfer", func(t *testing.T) {
+		// Transfer(address indexed from, address indexed to, uint256 value)
+		// has the same signature but the amount in the data
+		decoded := DecodeLog(&types.Log{
+			Address: common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"),
+			Topics: []common.Hash{
+				transfer,
+				common.BytesToHash(from.Bytes()),
+				common.BytesToHash(to.Bytes()),
+			},
+			Data: common.BigToHash(big.NewInt(1000000)).Bytes(),
+		})
+
+		if decoded.Event != "" || decoded.Args != nil {
+			t.Errorf("decoded as %s %v, want no match", decoded.Event, decoded.Args)
+		}
+	})
+
+	t.Run("anonymous log", func(t *testing.T) {
+		decoded := DecodeLog(&types.Log{Address: to})
+		if decoded.Event != "" || decoded.Address != to {
+			t.Errorf("decoded = %+v, want only the address", decoded)
+		}
+	})
+}
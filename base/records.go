@@ -0,0 +1,108 @@
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TextRecordKeys are the well-known text record keys used by Basenames profiles.
+var TextRecordKeys = []string{
+	"avatar",
+	"description",
+	"keywords",
+	"url",
+	"email",
+	"location",
+	"com.twitter",
+	"com.github",
+	"com.discord",
+	"xyz.farcaster",
+	"org.telegram",
+}
+
+// TextRecord is a single resolver text record.
+type TextRecord struct {
+	Key   string
+	Value string
+}
+
+// nameResolver returns the node of a basename and a binding to its resolver.
+func (c *Client) nameResolver(ctx context.Context, basename *Basename) (common.Hash, *Contract, error) {
+	node, err := basename.Node()
+	if err != nil {
+		return common.Hash{}, nil, err
+	}
+
+	resolver, err := c.ResolverOf(ctx, node)
+	if err != nil {
+		return common.Hash{}, nil, err
+	}
+	if resolver == (common.Address{}) {
+		return common.Hash{}, nil, Errorf(KindNotFound, "no resolver set for %s", basename.Name())
+	}
+
+	contract := c.NewResolverContract(resolver)
+	return node, contract, nil
+}
+
+// GetTextRecords reads the given text record keys from a basename's resolver
+// in a single multicall batch.
+func (c *Client) GetTextRecords(ctx context.Context, basename *Basename, keys []string) ([]TextRecord, error) {
+	node, resolver, err := c.nameResolver(ctx, basename)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]BatchCall, 0, len(keys))
+	for _, key := range keys {
+		calls = append(calls, BatchCall{Contract: resolver, Method: "text", Args: []interface{}{node, key}})
+	}
+
+	results, err := c.Multicall(ctx, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]TextRecord, 0, len(keys))
+	for i, key := range keys {
+		if results[i].Err != nil {
+			return nil, fmt.Errorf("failed to read %q: %w", key, results[i].Err)
+		}
+		records = append(records, TextRecord{Key: key, Value: results[i].Outputs[0].(string)})
+	}
+	return records, nil
+}
+
+// SetTextRecords writes text records to a basename's resolver. A single record
+// is sent as setText; several records are batched into one multicall.
+func (c *Client) SetTextRecords(ctx context.Context, basename *Basename, records []TextRecord) (*TxResult, error) {
+	if len(records) == 0 {
+		return nil, Errorf(KindInvalidInput, "no records to set")
+	}
+
+	node, resolver, err := c.nameResolver(ctx, basename)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([][]byte, 0, len(records))
+	for _, record := range records {
+		data, err := resolver.ABI.Pack("setText", node, record.Key, record.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode setText call: %v", err)
+		}
+		calls = append(calls, data)
+	}
+
+	data := calls[0]
+	if len(calls) > 1 {
+		data, err = resolver.ABI.Pack("multicall", calls)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode multicall: %v", err)
+		}
+	}
+
+	return c.WriteContract(ctx, resolver.Address, data, nil)
+}
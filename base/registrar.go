@@ -0,0 +1,169 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const secondsPerYear = 365 * 24 * 60 * 60
+
+// RegisterRequest mirrors the registrar controller's RegisterRequest struct.
+// Field names must match the ABI tuple components for packing.
+type RegisterRequest struct {
+	Name          string
+	Owner         common.Address
+	Duration      *big.Int
+	Resolver      common.Address
+	Data          [][]byte
+	ReverseRecord bool
+}
+
+// Price mirrors the price oracle's Price struct.
+type Price struct {
+	Base    *big.Int
+	Premium *big.Int
+}
+
+// YearsToDuration converts a number of years into a registration duration in seconds.
+func YearsToDuration(years int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(years), big.NewInt(secondsPerYear))
+}
+
+// IsNameAvailable asks the registrar controller whether a label can be registered.
+func (c *Client) IsNameAvailable(ctx context.Context, label string) (bool, error) {
+	controller := c.NewRegistrarControllerContract()
+
+	outputs, err := c.Call(ctx, controller, "available", label)
+	if err != nil {
+		return false, err
+	}
+	return outputs[0].(bool), nil
+}
+
+// RegisterPrice returns the price in wei, including any premium, to register
+// a label for the given duration.
+func (c *Client) RegisterPrice(ctx context.Context, label string, duration *big.Int) (*big.Int, error) {
+	controller := c.NewRegistrarControllerContract()
+
+	outputs, err := c.Call(ctx, controller, "registerPrice", label, duration)
+	if err != nil {
+		return nil, err
+	}
+	return outputs[0].(*big.Int), nil
+}
+
+// RentPrice returns the base price in wei to extend a label's registration by
+// the given duration. Renewals do not pay the expiry premium.
+func (c *Client) RentPrice(ctx context.Context, label string, duration *big.Int) (*big.Int, error) {
+	controller := c.NewRegistrarControllerContract()
+
+	outputs, err := c.Call(ctx, controller, "rentPrice", label, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	price := abi.ConvertType(outputs[0], new(Price)).(*Price)
+	return price.Base, nil
+}
+
+// NewRegisterRequest builds a registration request that points the name at the
+// L2 resolver and sets its address record to the owner.
+func (c *Client) NewRegisterRequest(basename *Basename, owner common.Address, duration *big.Int, reverseRecord bool) (*RegisterRequest, error) {
+	node, err := basename.Node()
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := c.NewL2ResolverContract()
+
+	setAddr, err := resolver.ABI.Pack("setAddr", node, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode setAddr call: %v", err)
+	}
+
+	return &RegisterRequest{
+		Name:          basename.Label,
+		Owner:         owner,
+		Duration:      duration,
+		Resolver:      resolver.Address,
+		Data:          [][]byte{setAddr},
+		ReverseRecord: reverseRecord,
+	}, nil
+}
+
+// Register sends the registration request to the controller with the given payment.
+func (c *Client) Register(ctx context.Context, request *RegisterRequest, value *big.Int) (*TxResult, error) {
+	controller := c.NewRegistrarControllerContract()
+
+	data, err := controller.ABI.Pack("register", *request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode register call: %v", err)
+	}
+
+	return c.WriteContract(ctx, controller.Address, data, value)
+}
+
+// Renew extends a label's registration by the given duration with the given payment.
+func (c *Client) Renew(ctx context.Context, label string, duration *big.Int, value *big.Int) (*TxResult, error) {
+	controller := c.NewRegistrarControllerContract()
+
+	data, err := controller.ABI.Pack("renew", label, duration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode renew call: %v", err)
+	}
+
+	return c.WriteContract(ctx, controller.Address, data, value)
+}
+
+// Reclaim sets the registry owner of a basename's node to owner. Only the
+// token owner or an approved address may call it.
+func (c *Client) Reclaim(ctx context.Context, tokenId *big.Int, owner common.Address) (*TxResult, error) {
+	contract := c.NewBasenamesContract()
+
+	data, err := contract.ABI.Pack("reclaim", tokenId, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode reclaim call: %v", err)
+	}
+
+	return c.WriteContract(ctx, contract.Address, data, nil)
+}
+
+// OwnerOf returns the current owner of a basename token.
+func (c *Client) OwnerOf(ctx context.Context, tokenId *big.Int) (common.Address, error) {
+	contract := c.NewBasenamesContract()
+
+	outputs, err := c.Call(ctx, contract, "ownerOf", tokenId)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return outputs[0].(common.Address), nil
+}
+
+// NameExpires returns the expiry time of a basename token.
+func (c *Client) NameExpires(ctx context.Context, tokenId *big.Int) (time.Time, error) {
+	contract := c.NewBasenamesContract()
+
+	outputs, err := c.Call(ctx, contract, "nameExpires", tokenId)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(outputs[0].(*big.Int).Int64(), 0), nil
+}
+
+// SafeTransfer sends a basename token from one address to another using the
+// registrar's safeTransferFrom(address,address,uint256).
+func (c *Client) SafeTransfer(ctx context.Context, from, to common.Address, tokenId *big.Int) (*TxResult, error) {
+	contract := c.NewBasenamesContract()
+
+	data, err := contract.ABI.Pack("safeTransferFrom", from, to, tokenId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode safeTransferFrom call: %v", err)
+	}
+
+	return c.WriteContract(ctx, contract.Address, data, nil)
+}
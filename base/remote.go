@@ -0,0 +1,145 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RemoteSigner has transactions signed by an external signer over JSON-RPC,
+// with Clef's account_signTransaction method. The keys never enter this
+// process.
+type RemoteSigner struct {
+	url     string
+	client  *rpc.Client
+	address common.Address
+}
+
+// signTxArgs are the parameters of account_signTransaction.
+type signTxArgs struct {
+	From                 common.MixedcaseAddress  `json:"from"`
+	To                   *common.MixedcaseAddress `json:"to"`
+	Gas                  hexutil.Uint64           `json:"gas"`
+	GasPrice             *hexutil.Big             `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big             `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big             `json:"maxPriorityFeePerGas,omitempty"`
+	Value                hexutil.Big              `json:"value"`
+	Nonce                hexutil.Uint64           `json:"nonce"`
+	Data                 *hexutil.Bytes           `json:"data"`
+	ChainID              *hexutil.Big             `json:"chainId"`
+}
+
+// NewRemoteSigner connects to the signer at url, an HTTP or WebSocket URL or
+// the path of an IPC socket. Without an address, the signer must manage a
+// single account, which is looked up with account_list.
+func NewRemoteSigner(ctx context.Context, url, address string, httpClient *http.Client) (*RemoteSigner, error) {
+	if url == "" {
+		return nil, Errorf(KindInvalidInput, "no remote signer configured, set %s or remote.url in the config file", BASENAMES_REMOTE_URL)
+	}
+	if address != "" && !common.IsHexAddress(address) {
+		return nil, Errorf(KindInvalidInput, "invalid remote signer account %q", address)
+	}
+
+	client, err := rpc.DialOptions(ctx, url, rpc.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, Errorf(KindInvalidInput, "invalid remote signer URL %s: %v", url, err)
+	}
+	s := &RemoteSigner{url: url, client: client}
+	if address != "" {
+		s.address = common.HexToAddress(address)
+		return s, nil
+	}
+
+	var addresses []common.Address
+	if err := client.CallContext(ctx, &addresses, "account_list"); err != nil {
+		client.Close()
+		return nil, s.error("account_list", err)
+	}
+	switch len(addresses) {
+	case 0:
+		client.Close()
+		return nil, Errorf(KindNotFound, "remote signer %s has no accounts", url)
+	case 1:
+		s.address = addresses[0]
+		return s, nil
+	}
+	client.Close()
+	return nil, Errorf(KindInvalidInput, "remote signer %s has %d accounts, set remote.account to choose one", url, len(addresses))
+}
+
+// Close closes the connection to the remote signer.
+func (s *RemoteSigner) Close() {
+	s.client.Close()
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx asks the remote signer to sign tx. It blocks until the signer
+// answers, which may need a manual approval. The signed transaction must be
+// the one that was sent, from the signer's account.
+func (s *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	data := hexutil.Bytes(tx.Data())
+	args := signTxArgs{
+		From:    common.NewMixedcaseAddress(s.address),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    &data,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if to := tx.To(); to != nil {
+		mixedTo := common.NewMixedcaseAddress(*to)
+		args.To = &mixedTo
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+
+	var result struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := s.client.CallContext(ctx, &result, "account_signTransaction", args); err != nil {
+		return nil, s.error("account_signTransaction", err)
+	}
+
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(result.Raw); err != nil {
+		return nil, fmt.Errorf("remote signer %s returned an invalid transaction: %w", s.url, err)
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	if signer.Hash(signedTx) != signer.Hash(tx) {
+		return nil, fmt.Errorf("remote signer %s changed the transaction, refusing to send it", s.url)
+	}
+	from, err := types.Sender(signer, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer %s returned an invalid signature: %w", s.url, err)
+	}
+	if from != s.address {
+		return nil, fmt.Errorf("remote signer %s signed as %s instead of %s", s.url, from.Hex(), s.address.Hex())
+	}
+	return signedTx, nil
+}
+
+// error describes a failed call of method on the remote signer. Errors
+// returned by the signer, such as a denied request, keep their message;
+// transport errors are tagged as RPC errors.
+func (s *RemoteSigner) error(method string, err error) error {
+	err = fmt.Errorf("remote signer %s: %s failed: %w", s.url, method, err)
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return &Error{Kind: KindRPC, Err: err}
+}
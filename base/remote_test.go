@@ -0,0 +1,214 @@
+package base
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// stubSigner stands in for Clef's account API.
+type stubSigner struct {
+	key      *ecdsa.PrivateKey
+	accounts []common.Address
+	// tamper bumps the nonce of the transactions it signs
+	tamper bool
+}
+
+func (s *stubSigner) List() []common.Address {
+	return s.accounts
+}
+
+func (s *stubSigner) SignTransaction(args signTxArgs) (map[string]interface{}, error) {
+	nonce := uint64(args.Nonce)
+	if s.tamper {
+		nonce++
+	}
+	to := args.To.Address()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   args.ChainID.ToInt(),
+		Nonce:     nonce,
+		GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+		GasFeeCap: args.MaxFeePerGas.ToInt(),
+		Gas:       uint64(args.Gas),
+		To:        &to,
+		Value:     args.Value.ToInt(),
+		Data:      *args.Data,
+	})
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(args.ChainID.ToInt()), s.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signedTx}, nil
+}
+
+// newStubSigner serves stub over JSON-RPC on a local HTTP server and
+// returns its URL.
+func newStubSigner(t *testing.T, stub *stubSigner) string {
+	t.Helper()
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", stub); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return httpServer.URL
+}
+
+func newTestKey(t *testing.T) (*ecdsa.PrivateKey, common.Address) {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func testTransaction() *types.Transaction {
+	to := BaseMainnet.Contracts.RegistrarController
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   new(big.Int).SetUint64(BaseMainnet.ChainID),
+		Nonce:     7,
+		GasTipCap: big.NewInt(1000000),
+		GasFeeCap: big.NewInt(2000000000),
+		Gas:       250000,
+		To:        &to,
+		Value:     big.NewInt(1000),
+		Data:      []byte{0xc4, 0x75, 0xab, 0xff},
+	})
+}
+
+func TestRemoteSigner(t *testing.T) {
+	ctx := context.Background()
+	chainID := new(big.Int).SetUint64(BaseMainnet.ChainID)
+	key, address := newTestKey(t)
+	url := newStubSigner(t, &stubSigner{key: key, accounts: []common.Address{address}})
+
+	signer, err := NewRemoteSigner(ctx, url, "", http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer.Address() != address {
+		t.Fatalf("address = %s, want %s", signer.Address().Hex(), address.Hex())
+	}
+
+	tx := testTransaction()
+	signedTx, err := signer.SignTx(ctx, tx, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest := types.LatestSignerForChainID(chainID)
+	if latest.Hash(signedTx) != latest.Hash(tx) {
+		t.Error("signed transaction differs from the requested one")
+	}
+	from, err := types.Sender(latest, signedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != address {
+		t.Errorf("sender = %s, want %s", from.Hex(), address.Hex())
+	}
+}
+
+func TestRemoteSignerRefusesChangedTransaction(t *testing.T) {
+	ctx := context.Background()
+	key, address := newTestKey(t)
+	url := newStubSigner(t, &stubSigner{key: key, accounts: []common.Address{address}, tamper: true})
+
+	signer, err := NewRemoteSigner(ctx, url, "", http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = signer.SignTx(ctx, testTransaction(), new(big.Int).SetUint64(BaseMainnet.ChainID))
+	if err == nil || !strings.Contains(err.Error(), "changed the transaction") {
+		t.Errorf("error = %v, want a changed transaction error", err)
+	}
+}
+
+func TestRemoteSignerRefusesWrongAccount(t *testing.T) {
+	ctx := context.Background()
+	key, address := newTestKey(t)
+	_, other := newTestKey(t)
+	url := newStubSigner(t, &stubSigner{key: key, accounts: []common.Address{address, other}})
+
+	// The stub signs with key whatever account it is asked for
+	signer, err := NewRemoteSigner(ctx, url, other.Hex(), http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = signer.SignTx(ctx, testTransaction(), new(big.Int).SetUint64(BaseMainnet.ChainID))
+	if err == nil || !strings.Contains(err.Error(), "signed as "+address.Hex()) {
+		t.Errorf("error = %v, want a wrong account error", err)
+	}
+}
+
+func TestRemoteSignerAccounts(t *testing.T) {
+	key, address := newTestKey(t)
+	_, other := newTestKey(t)
+
+	tests := []struct {
+		name     string
+		accounts []common.Address
+		kind     ErrorKind
+	}{
+		{"no accounts", nil, KindNotFound},
+		{"several accounts", []common.Address{address, other}, KindInvalidInput},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			url := newStubSigner(t, &stubSigner{key: key, accounts: test.accounts})
+
+			_, err := NewRemoteSigner(context.Background(), url, "", http.DefaultClient)
+			if KindOf(err) != test.kind {
+				t.Errorf("error = %v (%s), want %s", err, KindOf(err), test.kind)
+			}
+		})
+	}
+}
+
+func TestClientCloseClosesRemoteSigner(t *testing.T) {
+	ctx := context.Background()
+	key, address := newTestKey(t)
+	// Closing an HTTP client is a no-op, the connection to close is a
+	// WebSocket
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", &stubSigner{key: key, accounts: []common.Address{address}}); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server.WebsocketHandler(nil))
+	defer httpServer.Close()
+	defer server.Stop()
+
+	signer, err := NewRemoteSigner(ctx, "ws"+strings.TrimPrefix(httpServer.URL, "http"), "", http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &Client{}
+	client.SetSigner(signer)
+	client.Close()
+
+	_, err = signer.SignTx(ctx, testTransaction(), new(big.Int).SetUint64(BaseMainnet.ChainID))
+	if !errors.Is(err, rpc.ErrClientQuit) {
+		t.Errorf("error = %v, want %v", err, rpc.ErrClientQuit)
+	}
+}
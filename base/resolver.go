@@ -0,0 +1,131 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrNoPrimaryName is returned by ReverseResolve when an address has no
+// verified primary name.
+var ErrNoPrimaryName = errors.New("no primary name set")
+
+// ReverseNode returns the node of an address under a reverse namespace, such
+// as 80002105.reverse for Base.
+func ReverseNode(address common.Address, namespace string) common.Hash {
+	return Namehash(strings.ToLower(address.Hex()[2:]) + "." + namespace)
+}
+
+// ResolverOf returns the resolver set for a node in the Base ENS registry.
+func (c *Client) ResolverOf(ctx context.Context, node common.Hash) (common.Address, error) {
+	registry := c.NewRegistryContract()
+
+	outputs, err := c.Call(ctx, registry, "resolver", node)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return outputs[0].(common.Address), nil
+}
+
+// RegistryOwner returns the owner of a node in the Base ENS registry.
+func (c *Client) RegistryOwner(ctx context.Context, node common.Hash) (common.Address, error) {
+	registry := c.NewRegistryContract()
+
+	outputs, err := c.Call(ctx, registry, "owner", node)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return outputs[0].(common.Address), nil
+}
+
+// Resolve looks up the resolver for a basename and returns its addr record
+// along with the resolver it was read from.
+func (c *Client) Resolve(ctx context.Context, basename *Basename) (address common.Address, resolver common.Address, err error) {
+	node, err := basename.Node()
+	if err != nil {
+		return common.Address{}, common.Address{}, err
+	}
+
+	resolver, err = c.ResolverOf(ctx, node)
+	if err != nil {
+		return common.Address{}, common.Address{}, err
+	}
+	if resolver == (common.Address{}) {
+		return common.Address{}, resolver, Errorf(KindNotFound, "no resolver set for %s", basename.Name())
+	}
+
+	contract := c.NewResolverContract(resolver)
+
+	outputs, err := c.Call(ctx, contract, "addr", node)
+	if err != nil {
+		return common.Address{}, resolver, err
+	}
+	return outputs[0].(common.Address), resolver, nil
+}
+
+// ReverseResolve returns the primary basename of an address, read from the
+// network's reverse namespace. The name is only returned if it
+// forward-resolves back to the same address.
+func (c *Client) ReverseResolve(ctx context.Context, address common.Address) (string, error) {
+	node := ReverseNode(address, c.Network.ReverseNamespace)
+
+	resolver, err := c.ResolverOf(ctx, node)
+	if err != nil {
+		return "", err
+	}
+	if resolver == (common.Address{}) {
+		return "", ErrNoPrimaryName
+	}
+
+	contract := c.NewResolverContract(resolver)
+
+	outputs, err := c.Call(ctx, contract, "name", node)
+	if err != nil {
+		return "", err
+	}
+	name := outputs[0].(string)
+	if name == "" {
+		return "", ErrNoPrimaryName
+	}
+
+	basename, err := c.ParseBasename(name)
+	if err != nil || basename.Label == "" {
+		return "", Errorf(KindNotFound, "primary name %q is not a basename", name)
+	}
+
+	// Wrapped so that the kind of the lookup error, such as RPC, is kept
+	forward, _, err := c.Resolve(ctx, basename)
+	if err != nil {
+		return "", fmt.Errorf("failed to verify primary name %s: %w", name, err)
+	}
+	if forward != address {
+		return "", Errorf(KindNotFound, "primary name %s resolves to %s, not %s", name, forward.Hex(), address.Hex())
+	}
+
+	return basename.Name(), nil
+}
+
+// ResolveAddress accepts either a hex address or a basename and returns the
+// address it refers to.
+func (c *Client) ResolveAddress(ctx context.Context, input string) (common.Address, error) {
+	if common.IsHexAddress(input) {
+		return common.HexToAddress(input), nil
+	}
+
+	basename, err := c.ParseBasename(input)
+	if err != nil {
+		return common.Address{}, Errorf(KindInvalidInput, "%q is neither an address nor a basename: %v", input, err)
+	}
+
+	address, _, err := c.Resolve(ctx, basename)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to resolve %s: %w", basename, err)
+	}
+	if address == (common.Address{}) {
+		return common.Address{}, Errorf(KindNotFound, "%s has no address record", basename)
+	}
+	return address, nil
+}
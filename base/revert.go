@@ -0,0 +1,128 @@
+package base
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+
+// RevertError is a contract revert decoded against the known ABIs.
+type RevertError struct {
+	// Name is the custom error name, "Error" for require messages, "Panic"
+	// for assertion failures, or empty when the selector is unknown.
+	Name string
+	Args []RevertArg
+	// Data is the raw revert data returned by the node.
+	Data []byte
+}
+
+// RevertArg is a single named argument of a custom error.
+type RevertArg struct {
+	Name  string
+	Value interface{}
+}
+
+func (e *RevertError) Error() string {
+	if e.Name == "" {
+		if len(e.Data) == 0 {
+			return "execution reverted"
+		}
+		return fmt.Sprintf("execution reverted with unknown error %s", hexutil.Encode(e.Data))
+	}
+
+	args := make([]string, 0, len(e.Args))
+	for _, arg := range e.Args {
+		if arg.Name == "" {
+			args = append(args, formatRevertValue(arg.Value))
+			continue
+		}
+		args = append(args, fmt.Sprintf("%s=%s", arg.Name, formatRevertValue(arg.Value)))
+	}
+	return fmt.Sprintf("execution reverted: %s(%s)", e.Name, strings.Join(args, ", "))
+}
+
+// DecodeRevert returns a *RevertError when err carries revert data from the
+// node, and err unchanged otherwise.
+func DecodeRevert(err error) error {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return err
+	}
+
+	encoded, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return err
+	}
+	data, decodeErr := hexutil.Decode(encoded)
+	if decodeErr != nil {
+		return err
+	}
+
+	return decodeRevertData(data)
+}
+
+func decodeRevertData(data []byte) *RevertError {
+	revert := &RevertError{Data: data}
+	if len(data) < 4 {
+		return revert
+	}
+
+	// Standard Error(string) and Panic(uint256) reverts
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		if bytes.Equal(data[:4], panicSelector) {
+			revert.Name = "Panic"
+		} else {
+			revert.Name = "Error"
+		}
+		revert.Args = []RevertArg{{Value: reason}}
+		return revert
+	}
+
+	for _, contractABI := range knownABIs() {
+		for _, abiError := range contractABI.Errors {
+			if !bytes.Equal(abiError.ID[:4], data[:4]) {
+				continue
+			}
+
+			values, err := abiError.Inputs.Unpack(data[4:])
+			if err != nil {
+				continue
+			}
+
+			revert.Name = abiError.Name
+			for i, input := range abiError.Inputs {
+				revert.Args = append(revert.Args, RevertArg{Name: input.Name, Value: values[i]})
+			}
+			return revert
+		}
+	}
+
+	return revert
+}
+
+func formatRevertValue(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case [32]byte:
+		return common.Hash(v).Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case *big.Int:
+		return v.String()
+	case string:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
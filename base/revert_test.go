@@ -0,0 +1,66 @@
+package base
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// word left-pads a hex value to a 32-byte ABI word.
+func word(hex string) string {
+	return strings.Repeat("0", 64-len(hex)) + hex
+}
+
+func TestDecodeRevertData(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		revert  string
+		message string
+	}{
+		{
+			name: "custom error",
+			// NotApprovedOwner(uint256 tokenId, address sender)
+			data:    "0x5de10a05" + word("7b") + word("2c7536e3605d9c16a7a3d7b1898e529396a65c23"),
+			revert:  "NotApprovedOwner",
+			message: "execution reverted: NotApprovedOwner(tokenId=123, sender=0x2c7536E3605D9C16a7a3D7b1898e529396a65c23)",
+		},
+		{
+			name: "require message",
+			// Error(string) with "not owner"
+			data:    "0x08c379a0" + word("20") + word("9") + "6e6f74206f776e6572" + strings.Repeat("0", 46),
+			revert:  "Error",
+			message: `execution reverted: Error("not owner")`,
+		},
+		{
+			name: "panic",
+			// Panic(uint256) with the arithmetic overflow code
+			data:    "0x4e487b71" + word("11"),
+			revert:  "Panic",
+			message: `execution reverted: Panic("arithmetic underflow or overflow")`,
+		},
+		{
+			name:    "unknown selector",
+			data:    "0xdeadbeef" + word("1"),
+			message: "execution reverted with unknown error 0xdeadbeef" + word("1"),
+		},
+		{
+			name:    "no data",
+			data:    "0x",
+			message: "execution reverted",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			revert := decodeRevertData(common.FromHex(test.data))
+			if revert.Name != test.revert {
+				t.Errorf("name = %q, want %q", revert.Name, test.revert)
+			}
+			if message := revert.Error(); message != test.message {
+				t.Errorf("message = %s, want %s", message, test.message)
+			}
+		})
+	}
+}
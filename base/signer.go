@@ -0,0 +1,71 @@
+package base
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs transactions for a single account. The client builds each
+// transaction and hands it to the signer, so keys can live outside the
+// process.
+type Signer interface {
+	// Address returns the account the signer signs for.
+	Address() common.Address
+	// SignTx returns tx signed for chainID. It may block, for example to
+	// prompt for a password, until ctx is done.
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// NewSigner creates the signer selected by config.Type. Remote signers use
+// httpClient to reach the signing endpoint.
+func NewSigner(ctx context.Context, config SignerConfig, httpClient *http.Client) (Signer, error) {
+	switch config.Type {
+	case SignerPrivateKey:
+		if config.PrivateKey == "" {
+			return nil, Errorf(KindInvalidInput, "no private key configured, set %s or private_key in the config file", BASENAMES_PRIVATE_KEY)
+		}
+		return NewKeySigner(config.PrivateKey)
+	case SignerKeystore:
+		return NewKeystoreSigner(config.KeystoreDir, config.KeystoreAccount, config.Password)
+	case SignerMnemonic:
+		return NewMnemonicSigner(config.Mnemonic, config.MnemonicPath)
+	case SignerRemote:
+		return NewRemoteSigner(ctx, config.RemoteURL, config.RemoteAccount, httpClient)
+	}
+	return nil, Errorf(KindInvalidInput, "unknown signer %q, expected one of %s", config.Type, strings.Join(SignerTypes(), ", "))
+}
+
+// KeySigner signs with a private key held in memory.
+type KeySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewKeySigner creates a signer from a hex private key, with or without the
+// 0x prefix.
+func NewKeySigner(hexKey string) (*KeySigner, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+	if err != nil {
+		return nil, Errorf(KindInvalidInput, "invalid private key: %v", err)
+	}
+	return newKeySigner(key), nil
+}
+
+func newKeySigner(key *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+func (s *KeySigner) Address() common.Address {
+	return s.address
+}
+
+func (s *KeySigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
@@ -15,6 +15,23 @@ func WeiToEth(wei *big.Int) string {
 	return eth.Text('f', 18)
 }
 
+// WeiToGwei converts wei to gwei
+func WeiToGwei(wei *big.Int) string {
+	gwei := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e9))
+	return gwei.Text('f', 9)
+}
+
+// GweiToWei parses a decimal gwei amount such as "1.5" into wei
+func GweiToWei(gwei string) (*big.Int, error) {
+	amount, success := new(big.Float).SetPrec(256).SetString(gwei)
+	// SetString accepts "inf", which has no integer value
+	if !success || amount.Sign() < 0 || amount.IsInf() {
+		return nil, Errorf(KindInvalidInput, "invalid gwei amount %q", gwei)
+	}
+	wei, _ := new(big.Float).Mul(amount, big.NewFloat(1e9)).Int(nil)
+	return wei, nil
+}
+
 func GetAddressFromPrivateKey(privateKey string) (string, error) {
 	// Convert the private key from hex to bytes
 	privateKeyBytes, err := hex.DecodeString(privateKey)
@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hughescoin/basenames-cli/base"
+	"github.com/spf13/cobra"
+)
+
+type accountOutput struct {
+	Address string `json:"address"`
+	Path    string `json:"path"`
+	// Selected is set for the account the keystore signer uses
+	Selected bool `json:"selected"`
+}
+
+var accountCmd = &cobra.Command{
+	Use:   "account",
+	Short: "Manage the accounts of the encrypted keystore",
+	Long: `Manage the accounts of the encrypted keystore.
+
+Keys are stored as encrypted V3 JSON files in keystore.dir. Set signer to
+keystore to sign with them; the key is only decrypted to sign a transaction.
+Passwords are read from the terminal, or from --password-fd.`,
+}
+
+var accountNewCmd = &cobra.Command{
+	Use:         "new",
+	Short:       "Create a new account in the keystore",
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationOffline: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		password, err := readPassword(cmd.Context(), "Password for the new account", true)
+		if err != nil {
+			return err
+		}
+		if password == "" {
+			return invalidInputf("the password must not be empty")
+		}
+
+		ks := base.OpenKeystore(keystoreDir())
+		account, err := ks.NewAccount(password)
+		if err != nil {
+			return fmt.Errorf("failed to create account: %w", err)
+		}
+		return printAccount(ks, account, "Created")
+	},
+}
+
+var accountImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import a hex private key or a JSON key file into the keystore",
+	Long: `Import a hex private key or a V3 JSON key file into the keystore.
+
+Without a file, the configured private_key (for example from
+BASENAMES_PRIVATE_KEY) is imported. Use - to read the key from stdin. A hex
+key is encrypted with a new password; a JSON key file keeps its password.`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{annotationOffline: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		key, err := readKey(args)
+		if err != nil {
+			return err
+		}
+
+		ks := base.OpenKeystore(keystoreDir())
+		var account accounts.Account
+		if strings.HasPrefix(key, "{") {
+			password, err := readPassword(ctx, "Password of the key file", false)
+			if err != nil {
+				return err
+			}
+			account, err = ks.Import([]byte(key), password, password)
+			if err != nil {
+				return importError(err)
+			}
+		} else {
+			privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(key, "0x"))
+			if err != nil {
+				return invalidInputf("invalid private key: %v", err)
+			}
+			password, err := readPassword(ctx, "Password to encrypt the key", true)
+			if err != nil {
+				return err
+			}
+			if password == "" {
+				return invalidInputf("the password must not be empty")
+			}
+			account, err = ks.ImportECDSA(privateKey, password)
+			if err != nil {
+				return importError(err)
+			}
+		}
+
+		if len(args) == 0 {
+			logf("Unset %s and remove private_key from the config file before switching to the keystore signer\n", base.BASENAMES_PRIVATE_KEY)
+		}
+		return printAccount(ks, account, "Imported")
+	},
+}
+
+var accountListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List the accounts of the keystore",
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationOffline: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := keystoreDir()
+		ks := base.OpenKeystore(dir)
+		selected, _ := base.KeystoreAccount(ks, settingString("keystore.account"))
+
+		all := ks.Accounts()
+		outputs := make([]accountOutput, 0, len(all))
+		for _, account := range all {
+			outputs = append(outputs, newAccountOutput(account, selected))
+		}
+
+		return printResult(outputs, func() {
+			if len(outputs) == 0 {
+				fmt.Printf("No accounts in %s\n", dir)
+				return
+			}
+			for _, output := range outputs {
+				if output.Selected {
+					fmt.Printf("%s %s (selected)\n", output.Address, output.Path)
+					continue
+				}
+				fmt.Printf("%s %s\n", output.Address, output.Path)
+			}
+		})
+	},
+}
+
+var accountExportAddressCmd = &cobra.Command{
+	Use:         "export-address",
+	Short:       "Print the address of the selected keystore account",
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationOffline: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := keystoreDir()
+		account, err := base.KeystoreAccount(base.OpenKeystore(dir), settingString("keystore.account"))
+		if err != nil {
+			return fmt.Errorf("keystore %s: %w", dir, err)
+		}
+
+		output := newAccountOutput(account, account)
+		return printResult(output, func() {
+			fmt.Println(output.Address)
+		})
+	},
+}
+
+// readKey returns the key to import from the file argument, stdin ("-") or
+// the configured private key.
+func readKey(args []string) (string, error) {
+	if len(args) == 0 {
+		key := settingString("private_key")
+		if key == "" {
+			return "", invalidInputf("a key file or a configured private key is required")
+		}
+		return strings.TrimSpace(key), nil
+	}
+
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return "", invalidInputf("failed to read %s: %v", args[0], err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func importError(err error) error {
+	switch {
+	case errors.Is(err, keystore.ErrDecrypt):
+		return invalidInputf("failed to decrypt the key file: wrong password")
+	case errors.Is(err, keystore.ErrAccountAlreadyExists):
+		return invalidInputf("the account is already in the keystore")
+	}
+	return fmt.Errorf("failed to import key: %w", err)
+}
+
+func newAccountOutput(account, selected accounts.Account) accountOutput {
+	return accountOutput{
+		Address:  account.Address.Hex(),
+		Path:     account.URL.Path,
+		Selected: account.Address == selected.Address,
+	}
+}
+
+// printAccount prints an account that was just added to the keystore.
+func printAccount(ks *keystore.KeyStore, account accounts.Account, verb string) error {
+	selected, _ := base.KeystoreAccount(ks, settingString("keystore.account"))
+	output := newAccountOutput(account, selected)
+	if settingString("signer") != base.SignerKeystore {
+		logf("Sign with the keystore by running: basenames config set signer keystore\n")
+	}
+	return printResult(output, func() {
+		fmt.Printf("%s account %s\n", verb, output.Address)
+		fmt.Printf("Key file: %s\n", output.Path)
+	})
+}
+
+func init() {
+	rootCmd.AddCommand(accountCmd)
+	accountCmd.AddCommand(accountNewCmd)
+	accountCmd.AddCommand(accountImportCmd)
+	accountCmd.AddCommand(accountListCmd)
+	accountCmd.AddCommand(accountExportAddressCmd)
+}
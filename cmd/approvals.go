@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hughescoin/basenames-cli/base"
+	"github.com/spf13/cobra"
+)
+
+var approvalsOperator string
+
+type approvalOutput struct {
+	Name        string    `json:"name"`
+	TokenId     string    `json:"tokenId"`
+	Owner       string    `json:"owner"`
+	Approved    string    `json:"approved"`
+	Transaction *txOutput `json:"transaction,omitempty"`
+}
+
+type operatorOutput struct {
+	Owner       string    `json:"owner"`
+	Operator    string    `json:"operator"`
+	Approved    bool      `json:"approved"`
+	Transaction *txOutput `json:"transaction,omitempty"`
+}
+
+var approvalsCmd = &cobra.Command{
+	Use:   "approvals",
+	Short: "Inspect and manage ERC-721 approvals",
+}
+
+var approvalsShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show the owner and approved address of a basename",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		basename, err := base.BaseClient.ParseBasename(args[0])
+		if err != nil {
+			return err
+		}
+
+		owner, err := base.BaseClient.OwnerOf(ctx, basename.TokenId)
+		if err != nil {
+			return fmt.Errorf("failed to check owner: %w", err)
+		}
+		approved, err := base.BaseClient.GetApproved(ctx, basename.TokenId)
+		if err != nil {
+			return fmt.Errorf("failed to check approval: %w", err)
+		}
+
+		return printResult(newApprovalOutput(basename, owner, approved), func() {
+			fmt.Printf("Owner of %s: %s\n", basename.Describe(), owner.Hex())
+			if approved == (common.Address{}) {
+				fmt.Println("Approved: none")
+			} else {
+				fmt.Printf("Approved: %s\n", approved.Hex())
+			}
+		})
+	},
+}
+
+var approvalsOperatorsCmd = &cobra.Command{
+	Use:   "operators <owner> <operator>",
+	Short: "Check whether an operator is approved for all of an owner's basenames",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		owner, err := base.BaseClient.ResolveAddress(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		operator, err := base.BaseClient.ResolveAddress(ctx, args[1])
+		if err != nil {
+			return err
+		}
+
+		approved, err := base.BaseClient.IsApprovedForAll(ctx, owner, operator)
+		if err != nil {
+			return fmt.Errorf("failed to check operator approval: %w", err)
+		}
+
+		output := operatorOutput{Owner: owner.Hex(), Operator: operator.Hex(), Approved: approved}
+		return printResult(output, func() {
+			if approved {
+				fmt.Printf("%s is an approved operator for %s\n", operator.Hex(), owner.Hex())
+			} else {
+				fmt.Printf("%s is not an approved operator for %s\n", operator.Hex(), owner.Hex())
+			}
+		})
+	},
+}
+
+var approvalsGrantCmd = &cobra.Command{
+	Use:   "grant [<name> <spender> | --operator <address>]",
+	Short: "Approve a spender for one basename, or an operator for all of them",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if approvalsOperator != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	Annotations: map[string]string{annotationSigner: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		if approvalsOperator != "" {
+			return setOperatorApproval(ctx, approvalsOperator, true)
+		}
+
+		spender, err := base.BaseClient.ResolveAddress(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		return approveToken(ctx, args[0], spender)
+	},
+}
+
+var approvalsRevokeCmd = &cobra.Command{
+	Use:   "revoke [<name> | --operator <address>]",
+	Short: "Clear a basename's approval, or revoke an operator",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if approvalsOperator != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	Annotations: map[string]string{annotationSigner: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		if approvalsOperator != "" {
+			return setOperatorApproval(ctx, approvalsOperator, false)
+		}
+		return approveToken(ctx, args[0], common.Address{})
+	},
+}
+
+// approveToken approves spender for a single basename, or clears the
+// approval when spender is the zero address.
+func approveToken(ctx context.Context, name string, spender common.Address) error {
+	basename, err := base.BaseClient.ParseBasename(name)
+	if err != nil {
+		return err
+	}
+
+	signer := common.HexToAddress(base.BaseClient.Address)
+	owner, err := base.BaseClient.OwnerOf(ctx, basename.TokenId)
+	if err != nil {
+		return fmt.Errorf("failed to check owner: %w", err)
+	}
+	if owner != signer {
+		isOperator, err := base.BaseClient.IsApprovedForAll(ctx, owner, signer)
+		if err != nil {
+			return fmt.Errorf("failed to check operator approval: %w", err)
+		}
+		if !isOperator {
+			return invalidInputf("%s is owned by %s and the signer %s is not an approved operator", basename.Describe(), owner.Hex(), signer.Hex())
+		}
+	}
+
+	if spender == (common.Address{}) {
+		logf("Clearing approval for %s\n", basename.Describe())
+	} else {
+		logf("Approving %s to transfer %s\n", spender.Hex(), basename.Describe())
+	}
+	if err := confirm(ctx, "Proceed?"); err != nil {
+		return err
+	}
+
+	result, err := base.BaseClient.Approve(ctx, spender, basename.TokenId)
+	if err != nil {
+		return fmt.Errorf("failed to send approval: %w", err)
+	}
+
+	output := newApprovalOutput(basename, owner, spender)
+	output.Transaction = newTxOutput(result)
+	return printResult(output, func() {
+		printTxResult(result)
+		fmt.Println("Approval updated")
+	})
+}
+
+// setOperatorApproval grants or revokes an operator over all of the signer's basenames.
+func setOperatorApproval(ctx context.Context, input string, approved bool) error {
+	operator, err := base.BaseClient.ResolveAddress(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	if approved {
+		logf("Approving %s as an operator for all basenames of %s\n", operator.Hex(), base.BaseClient.Address)
+	} else {
+		logf("Revoking %s as an operator for all basenames of %s\n", operator.Hex(), base.BaseClient.Address)
+	}
+	if err := confirm(ctx, "Proceed?"); err != nil {
+		return err
+	}
+
+	result, err := base.BaseClient.SetApprovalForAll(ctx, operator, approved)
+	if err != nil {
+		return fmt.Errorf("failed to send approval: %w", err)
+	}
+
+	output := operatorOutput{
+		Owner:       common.HexToAddress(base.BaseClient.Address).Hex(),
+		Operator:    operator.Hex(),
+		Approved:    approved,
+		Transaction: newTxOutput(result),
+	}
+	return printResult(output, func() {
+		printTxResult(result)
+		fmt.Println("Operator approval updated")
+	})
+}
+
+// newApprovalOutput reports a cleared approval as an empty approved address.
+func newApprovalOutput(basename *base.Basename, owner, approved common.Address) approvalOutput {
+	output := approvalOutput{Name: basename.Name(), TokenId: basename.TokenId.String(), Owner: owner.Hex()}
+	if approved != (common.Address{}) {
+		output.Approved = approved.Hex()
+	}
+	return output
+}
+
+func init() {
+	rootCmd.AddCommand(approvalsCmd)
+	approvalsCmd.AddCommand(approvalsShowCmd)
+	approvalsCmd.AddCommand(approvalsOperatorsCmd)
+	approvalsCmd.AddCommand(approvalsGrantCmd)
+	approvalsCmd.AddCommand(approvalsRevokeCmd)
+
+	approvalsGrantCmd.Flags().StringVar(&approvalsOperator, "operator", "", "Operator address or basename to approve for all basenames")
+	approvalsRevokeCmd.Flags().StringVar(&approvalsOperator, "operator", "", "Operator address or basename to revoke")
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hughescoin/basenames-cli/base"
+)
+
+// batchChunkSize is the number of names looked up per LookupNames call.
+const batchChunkSize = 250
+
+var namesFile string
+
+// batchSummary counts the names of a batch check by status.
+type batchSummary struct {
+	Total     int `json:"total"`
+	Available int `json:"available"`
+	Taken     int `json:"taken"`
+	Grace     int `json:"grace"`
+	Errors    int `json:"errors"`
+}
+
+// batchOutput is the schema of a batch check in JSON and YAML. CSV output
+// only has the rows.
+type batchOutput struct {
+	Names   interface{}  `json:"names"`
+	Summary batchSummary `json:"summary"`
+}
+
+// batchEntry is one input name of a batch with its lookup result. Err is set
+// when the name could not be parsed or looked up.
+type batchEntry struct {
+	Input    string
+	Basename *base.Basename
+	Info     base.NameInfo
+	Status   string
+	Err      error
+}
+
+// Name returns the normalized name, or the input when it could not be parsed.
+func (e batchEntry) Name() string {
+	if e.Basename == nil {
+		return e.Input
+	}
+	return e.Basename.String()
+}
+
+// namesFromInput collects the names to check from the positional arguments,
+// --file and stdin. Stdin is only read when asked for with "-", so that a
+// command run with an open but idle stdin, as from cron, does not block.
+// batch is false when a single name was given as an argument or with
+// --tokenId, which keeps the single-name output.
+func namesFromInput(args []string) (names []string, batch bool, err error) {
+	if tokenId != "" && (len(args) > 0 || namesFile != "") {
+		return nil, false, invalidInputf("--tokenId cannot be combined with names or --file")
+	}
+
+	for _, arg := range args {
+		if arg == "-" {
+			stdinNames, err := readNames(os.Stdin)
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to read names from stdin: %w", err)
+			}
+			names = append(names, stdinNames...)
+			batch = true
+			continue
+		}
+		names = append(names, arg)
+	}
+
+	if namesFile != "" {
+		file := os.Stdin
+		if namesFile != "-" {
+			file, err = os.Open(namesFile)
+			if err != nil {
+				return nil, false, invalidInputf("failed to open %s: %v", namesFile, err)
+			}
+			defer file.Close()
+		}
+
+		fileNames, err := readNames(file)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read %s: %w", namesFile, err)
+		}
+		names = append(names, fileNames...)
+		batch = true
+	}
+
+	if tokenId != "" {
+		names = append(names, tokenId)
+	}
+	if len(names) == 0 {
+		if batch {
+			return nil, false, invalidInputf("no names to check")
+		}
+		return nil, false, invalidInputf("a basename or --tokenId is required")
+	}
+	return names, batch || len(names) > 1, nil
+}
+
+// readNames reads whitespace-separated names, one or more per line. Blank
+// lines and lines starting with # are skipped.
+func readNames(r io.Reader) ([]string, error) {
+	var names []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, strings.Fields(line)...)
+	}
+	return names, scanner.Err()
+}
+
+// lookupBatch parses and looks up every name through Multicall3, in chunks of
+// batchChunkSize names. Per-name failures are recorded on the entry; the
+// returned error is only set when a whole chunk fails.
+func lookupBatch(ctx context.Context, names []string) ([]batchEntry, error) {
+	entries := make([]batchEntry, len(names))
+	var basenames []*base.Basename
+	var indexes []int
+	for i, name := range names {
+		entries[i].Input = name
+		basename, err := base.BaseClient.ParseBasename(name)
+		if err != nil {
+			entries[i].Err = err
+			continue
+		}
+		entries[i].Basename = basename
+		basenames = append(basenames, basename)
+		indexes = append(indexes, i)
+	}
+
+	now := time.Now()
+	for start := 0; start < len(basenames); start += batchChunkSize {
+		end := start + batchChunkSize
+		if end > len(basenames) {
+			end = len(basenames)
+		}
+
+		infos, err := base.BaseClient.LookupNames(ctx, basenames[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for i, info := range infos {
+			entry := &entries[indexes[start+i]]
+			entry.Info = info
+			entry.Err = info.Err
+			if info.Err == nil {
+				entry.Status = info.Status(now)
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+func summarize(entries []batchEntry) batchSummary {
+	summary := batchSummary{Total: len(entries)}
+	for _, entry := range entries {
+		switch {
+		case entry.Err != nil:
+			summary.Errors++
+		case entry.Status == base.StatusAvailable:
+			summary.Available++
+		case entry.Status == base.StatusGrace:
+			summary.Grace++
+		default:
+			summary.Taken++
+		}
+	}
+	return summary
+}
+
+// printBatch prints the rows of a batch check followed by its summary. The
+// summary goes to stderr for CSV so stdout stays a single table. When any
+// name failed, an error wrapping the first failure is returned after the
+// rows are printed.
+func printBatch(entries []batchEntry, rows interface{}, text func()) error {
+	summary := summarize(entries)
+	summaryLine := fmt.Sprintf("%d name(s): %d available, %d taken, %d in grace, %d error(s)",
+		summary.Total, summary.Available, summary.Taken, summary.Grace, summary.Errors)
+
+	var err error
+	if outputFormat == outputCSV {
+		err = printResult(rows, nil)
+		logf("%s\n", summaryLine)
+	} else {
+		err = printResult(batchOutput{Names: rows, Summary: summary}, func() {
+			text()
+			fmt.Println(summaryLine)
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.Err != nil {
+			return fmt.Errorf("%d of %d name(s) could not be checked, first failure %s: %w", summary.Errors, summary.Total, entry.Name(), entry.Err)
+		}
+	}
+	return nil
+}
+
+// errorString returns the message of err, or an empty string when it is nil.
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"math/big"
+	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -12,193 +14,275 @@ import (
 
 var tokenId string
 
+// Status and Error are only set for batch checks.
+type availabilityOutput struct {
+	Name      string `json:"name"`
+	TokenId   string `json:"tokenId"`
+	Available bool   `json:"available"`
+	Status    string `json:"status,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type expirationOutput struct {
+	Name    string `json:"name"`
+	TokenId string `json:"tokenId"`
+	Expires string `json:"expires"`
+	Status  string `json:"status,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type ownerOutput struct {
+	Name    string `json:"name"`
+	TokenId string `json:"tokenId"`
+	Owner   string `json:"owner"`
+	Status  string `json:"status,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type balanceOutput struct {
+	Address string `json:"address"`
+	Wei     string `json:"wei"`
+	Eth     string `json:"eth"`
+}
+
+type blockOutput struct {
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+type endpointOutput struct {
+	URL         string `json:"url"`
+	Healthy     bool   `json:"healthy"`
+	ChainID     uint64 `json:"chainId"`
+	BlockNumber uint64 `json:"blockNumber"`
+	LatencyMs   int64  `json:"latencyMs"`
+	Reason      string `json:"reason"`
+}
+
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Check basename availability, expiration, or balance",
 }
 
 var availabilityCmd = &cobra.Command{
-	Use:   "availability",
-	Short: "Check a basename's availability",
-	Run: func(cmd *cobra.Command, args []string) {
-		if tokenId == "" {
-			fmt.Println("Error: tokenId is required for checking availability")
-			return
-		}
-		tokenIdBig, success := new(big.Int).SetString(tokenId, 10)
-		if !success {
-			fmt.Println("Error: Invalid tokenId format")
-			return
-		}
-		//initiatilize contract
-		contract, err := base.BaseClient.NewBasenamesContract()
+	Use:   "availability [name...]",
+	Short: "Check the availability of one or more basenames",
+	Args:  cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		names, batch, err := namesFromInput(args)
 		if err != nil {
-			fmt.Printf("Error creating contract instance: %v\n", err)
-			return
+			return err
+		}
+		if batch {
+			return checkAvailabilityBatch(ctx, names)
+		}
 
+		basename, err := base.BaseClient.ParseBasename(names[0])
+		if err != nil {
+			return err
 		}
+		//initiatilize contract
+		contract := base.BaseClient.NewBasenamesContract()
 
-		data, err := contract.ABI.Pack("isAvailable", tokenIdBig)
+		data, err := contract.ABI.Pack("isAvailable", basename.TokenId)
 		if err != nil {
-			fmt.Printf("Error encoding function call: %v\n", err)
-			return
+			return fmt.Errorf("failed to encode function call: %w", err)
 		}
 
-		result, err := base.BaseClient.ReadContract(contract.Address, data)
+		result, err := base.BaseClient.ReadContract(ctx, contract.Address, data)
 		if err != nil {
-			fmt.Printf("Error calling contract: %v\n", err)
-			return
+			return err
 		}
 
 		var availability bool
 		err = contract.ABI.UnpackIntoInterface(&availability, "isAvailable", result)
 		if err != nil {
-			fmt.Printf("Error decoding result: %v\n", err)
-			return
-		}
-
-		if availability == true {
-			fmt.Printf("%s is available \n", tokenId)
-		} else {
-			fmt.Printf("%s is not available \n", tokenId)
+			return fmt.Errorf("failed to decode result: %w", err)
 		}
 
+		output := availabilityOutput{Name: basename.Name(), TokenId: basename.TokenId.String(), Available: availability}
+		return printResult(output, func() {
+			if availability == true {
+				fmt.Printf("%s is available \n", basename.Describe())
+			} else {
+				fmt.Printf("%s is not available \n", basename.Describe())
+			}
+		})
 	},
 }
 
 var expirationCmd = &cobra.Command{
-	Use:   "expiration",
-	Short: "Check a basename's expiration",
-	Run: func(cmd *cobra.Command, args []string) {
-		if tokenId == "" {
-			fmt.Println("Error: tokenId is required for checking expiration")
-			return
-		}
-		fmt.Printf("Checking expiration for token ID: %s\n", tokenId)
+	Use:   "expiration [name...]",
+	Short: "Check the expiration of one or more basenames",
+	Args:  cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 
-		tokenIdBig, success := new(big.Int).SetString(tokenId, 10)
-		if !success {
-			fmt.Println("Error: Invalid tokenId format")
-			return
+		names, batch, err := namesFromInput(args)
+		if err != nil {
+			return err
+		}
+		if batch {
+			return checkExpirationBatch(ctx, names)
 		}
 
-		contract, err := base.BaseClient.NewBasenamesContract()
+		basename, err := base.BaseClient.ParseBasename(names[0])
 		if err != nil {
-
+			return err
 		}
+		logf("Checking expiration for %s\n", basename.Describe())
+
+		contract := base.BaseClient.NewBasenamesContract()
 
-		data, err := contract.ABI.Pack("nameExpires", tokenIdBig)
+		data, err := contract.ABI.Pack("nameExpires", basename.TokenId)
 		if err != nil {
-			fmt.Printf("Error encoding function call: %v\n", err)
-			return
+			return fmt.Errorf("failed to encode function call: %w", err)
 		}
 
-		result, err := base.BaseClient.ReadContract(contract.Address, data)
+		result, err := base.BaseClient.ReadContract(ctx, contract.Address, data)
 		if err != nil {
-			fmt.Printf("Error calling contract: %v\n", err)
-			return
+			return err
 		}
 
 		var epochTime big.Int
 		unpackResult, err := contract.ABI.Unpack("nameExpires", result)
 		if err != nil {
-			fmt.Printf("Error decoding result: %v\n", err)
-			return
+			return fmt.Errorf("failed to decode result: %w", err)
 		}
 
 		epochTime = *unpackResult[0].(*big.Int)
 		expirationTime := time.Unix(epochTime.Int64(), 0)
-		fmt.Printf("Expiration time for token ID %s: %s\n", tokenId, expirationTime.Format(time.RFC3339))
-		// TODO: Implement expiration check
+		output := expirationOutput{Name: basename.Name(), TokenId: basename.TokenId.String(), Expires: formatTime(expirationTime)}
+		return printResult(output, func() {
+			fmt.Printf("Expiration time for %s: %s\n", basename.Describe(), expirationTime.Format(time.RFC3339))
+		})
 	},
 }
 
 var balanceCmd = &cobra.Command{
 	Use:   "balance",
-	Short: "Check the balance of the current account",
-	Run: func(cmd *cobra.Command, args []string) {
-		if base.BaseClient == nil {
-			fmt.Println("Error: Client not initialized. Please ensure environment variables are set.")
-			return
+	Short: "Check the balance of --address or the signer's account",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		address, err := accountAddress(ctx)
+		if err != nil {
+			return err
 		}
-		accountBalance, err := base.BaseClient.GetBalance(base.BaseClient.Address)
+		accountBalance, err := base.BaseClient.GetBalance(ctx, address)
 		if err != nil {
-			fmt.Printf("Error checking block number: %v\n", err)
-			return
+			return err
 		}
 
 		accountBalanceBigInt, success := new(big.Int).SetString(accountBalance, 10)
 		if !success {
-			fmt.Println("Error: Invalid account balance format")
-			return
+			return fmt.Errorf("invalid account balance format %q", accountBalance)
 		}
-		accountBalance = base.WeiToEth(accountBalanceBigInt)
-		fmt.Printf("%s Account balance: %s ETH\n", base.BaseClient.Address, accountBalance)
+		output := balanceOutput{Address: address, Wei: accountBalance, Eth: base.WeiToEth(accountBalanceBigInt)}
+		return printResult(output, func() {
+			fmt.Printf("%s Account balance: %s ETH\n", output.Address, output.Eth)
+		})
 	},
 }
 
 var blockCmd = &cobra.Command{
 	Use:   "block",
 	Short: "Check the latest block number",
-	Run: func(cmd *cobra.Command, args []string) {
-		if base.BaseClient == nil {
-			fmt.Println("Error: Client not initialized. Please ensure environment variables are set.")
-			return
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		blockNumber, err := base.BaseClient.GetBlock(ctx)
+		if err != nil {
+			return err
 		}
-		blockNumber, err := base.BaseClient.GetBlock()
+		number, err := strconv.ParseUint(blockNumber, 10, 64)
 		if err != nil {
-			fmt.Printf("Error checking block number: %v\n", err)
-			return
+			return fmt.Errorf("invalid block number %q", blockNumber)
 		}
-		fmt.Printf("Latest block number: %s\n", blockNumber)
+		return printResult(blockOutput{BlockNumber: number}, func() {
+			fmt.Printf("Latest block number: %s\n", blockNumber)
+		})
 	},
 }
 
-var ownerCmd = &cobra.Command{
-	Use:   "ownerOf",
-	Short: "Check the owner of a basename",
-	Run: func(cmd *cobra.Command, args []string) {
-		if tokenId == "" {
-			fmt.Println("Error: tokenId is required for checking owner")
-			return
+var rpcCmd = &cobra.Command{
+	Use:   "rpc",
+	Short: "Check the health of the configured RPC endpoints",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		results := base.BaseClient.HealthCheck(ctx)
+		output := make([]endpointOutput, 0, len(results))
+		for _, health := range results {
+			output = append(output, endpointOutput{
+				URL:         health.URL,
+				Healthy:     health.Healthy,
+				ChainID:     health.ChainID,
+				BlockNumber: health.BlockNumber,
+				LatencyMs:   health.Latency.Milliseconds(),
+				Reason:      health.Reason,
+			})
 		}
 
-		// Convert tokenId string to big.Int
-		tokenIdBig, success := new(big.Int).SetString(tokenId, 10)
-		if !success {
-			fmt.Println("Error: Invalid tokenId format")
-			return
+		return printResult(output, func() {
+			for _, health := range results {
+				if health.Healthy {
+					fmt.Printf("%s: healthy (chain ID %d, block %d, %s)\n", health.URL, health.ChainID, health.BlockNumber, health.Latency.Round(time.Millisecond))
+				} else {
+					fmt.Printf("%s: unhealthy (%s)\n", health.URL, health.Reason)
+				}
+			}
+		})
+	},
+}
+
+var ownerCmd = &cobra.Command{
+	Use:   "ownerOf [name...]",
+	Short: "Check the owner of one or more basenames",
+	Args:  cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		names, batch, err := namesFromInput(args)
+		if err != nil {
+			return err
+		}
+		if batch {
+			return checkOwnerBatch(ctx, names)
 		}
 
-		contract, err := base.BaseClient.NewBasenamesContract()
+		// Resolve the name, labelhash or tokenId to a registrar tokenId
+		basename, err := base.BaseClient.ParseBasename(names[0])
 		if err != nil {
-			fmt.Printf("Error creating contract instance: %v\n", err)
-			return
+			return err
 		}
 
+		contract := base.BaseClient.NewBasenamesContract()
+
 		// Encode function call
-		data, err := contract.ABI.Pack("ownerOf", tokenIdBig)
+		data, err := contract.ABI.Pack("ownerOf", basename.TokenId)
 		if err != nil {
-			fmt.Printf("Error encoding function call: %v\n", err)
-			return
+			return fmt.Errorf("failed to encode function call: %w", err)
 		}
 
 		// Call the contract
-		result, err := base.BaseClient.ReadContract(contract.Address, data)
+		result, err := base.BaseClient.ReadContract(ctx, contract.Address, data)
 		if err != nil {
-			fmt.Printf("Error calling contract: %v\n", err)
-			return
+			return err
 		}
 
 		// Decode the result
 		var owner common.Address
 		err = contract.ABI.UnpackIntoInterface(&owner, "ownerOf", result)
 		if err != nil {
-			fmt.Printf("Error decoding result: %v\n", err)
-			return
+			return fmt.Errorf("failed to decode result: %w", err)
 		}
 
-		fmt.Printf("Owner of token %s: %s\n", tokenId, owner.Hex())
+		output := ownerOutput{Name: basename.Name(), TokenId: basename.TokenId.String(), Owner: owner.Hex()}
+		return printResult(output, func() {
+			fmt.Printf("Owner of %s: %s\n", basename.Describe(), owner.Hex())
+		})
 	},
 }
 
@@ -209,14 +293,107 @@ func init() {
 	checkCmd.AddCommand(balanceCmd)
 	checkCmd.AddCommand(blockCmd)
 	checkCmd.AddCommand(ownerCmd)
+	checkCmd.AddCommand(rpcCmd)
 
-	// Add tokenId flag to the check command, making it available to all subcommands
-	checkCmd.PersistentFlags().StringVar(&tokenId, "tokenId", "", "Token ID to check")
+	// Add tokenId flag to the check command, making it available to all subcommands.
+	// It accepts the same forms as the positional name argument.
+	checkCmd.PersistentFlags().StringVar(&tokenId, "tokenId", "", "Basename, labelhash or token ID to check")
 
-	// Require tokenId flag for availability and expiration subcommands
-	availabilityCmd.MarkFlagRequired("tokenId")
-	expirationCmd.MarkFlagRequired("tokenId")
+	// The name checks also take a list of names, one or more per line
+	for _, cmd := range []*cobra.Command{availabilityCmd, expirationCmd, ownerCmd} {
+		cmd.Flags().StringVar(&namesFile, "file", "", "File of names to check, or - for stdin")
+	}
+}
 
-	ownerCmd.Flags().StringVar(&tokenId, "tokenId", "", "Token ID to check owner")
-	ownerCmd.MarkFlagRequired("tokenId")
+func checkAvailabilityBatch(ctx context.Context, names []string) error {
+	entries, err := lookupBatch(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]availabilityOutput, 0, len(entries))
+	for _, entry := range entries {
+		row := availabilityOutput{Name: entry.Name(), Status: entry.Status, Error: errorString(entry.Err)}
+		if entry.Basename != nil {
+			row.TokenId = entry.Basename.TokenId.String()
+		}
+		row.Available = entry.Err == nil && entry.Info.Available
+		rows = append(rows, row)
+	}
+
+	return printBatch(entries, rows, func() {
+		for _, row := range rows {
+			if row.Error != "" {
+				fmt.Printf("%s: error: %s\n", row.Name, row.Error)
+				continue
+			}
+			fmt.Printf("%s: %s\n", row.Name, row.Status)
+		}
+	})
+}
+
+func checkExpirationBatch(ctx context.Context, names []string) error {
+	entries, err := lookupBatch(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]expirationOutput, 0, len(entries))
+	for _, entry := range entries {
+		row := expirationOutput{Name: entry.Name(), Status: entry.Status, Error: errorString(entry.Err)}
+		if entry.Basename != nil {
+			row.TokenId = entry.Basename.TokenId.String()
+		}
+		// Names that were never registered have no expiry
+		if entry.Err == nil && entry.Info.Expires.Unix() > 0 {
+			row.Expires = formatTime(entry.Info.Expires)
+		}
+		rows = append(rows, row)
+	}
+
+	return printBatch(entries, rows, func() {
+		for _, row := range rows {
+			switch {
+			case row.Error != "":
+				fmt.Printf("%s: error: %s\n", row.Name, row.Error)
+			case row.Expires == "":
+				fmt.Printf("%s: never registered (%s)\n", row.Name, row.Status)
+			default:
+				fmt.Printf("%s: expires %s (%s)\n", row.Name, row.Expires, row.Status)
+			}
+		}
+	})
+}
+
+func checkOwnerBatch(ctx context.Context, names []string) error {
+	entries, err := lookupBatch(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]ownerOutput, 0, len(entries))
+	for _, entry := range entries {
+		row := ownerOutput{Name: entry.Name(), Status: entry.Status, Error: errorString(entry.Err)}
+		if entry.Basename != nil {
+			row.TokenId = entry.Basename.TokenId.String()
+		}
+		// ownerOf reverts for expired and unregistered names, which have no owner
+		if entry.Err == nil && entry.Info.Owner != (common.Address{}) {
+			row.Owner = entry.Info.Owner.Hex()
+		}
+		rows = append(rows, row)
+	}
+
+	return printBatch(entries, rows, func() {
+		for _, row := range rows {
+			switch {
+			case row.Error != "":
+				fmt.Printf("%s: error: %s\n", row.Name, row.Error)
+			case row.Owner == "":
+				fmt.Printf("%s: no owner (%s)\n", row.Name, row.Status)
+			default:
+				fmt.Printf("%s: %s (%s)\n", row.Name, row.Owner, row.Status)
+			}
+		}
+	})
 }
@@ -0,0 +1,617 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hughescoin/basenames-cli/base"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// annotationOffline marks commands that never talk to the chain. They run
+// without building the client, so a broken configuration can still be fixed.
+const annotationOffline = "offline"
+
+// envPrefix prefixes the environment variable of every setting.
+const envPrefix = "BASENAMES_"
+
+// defaultKeystoreDir is where account new and account import store keys.
+const defaultKeystoreDir = "~/.basenames/keystore"
+
+// setting is a configuration key. Every setting can be given in the config
+// file or as an environment variable; some also have a flag. Flags take
+// precedence over environment variables, which take precedence over the
+// config file.
+type setting struct {
+	key string
+	// flag is the persistent flag bound to the setting, if any
+	flag string
+	// secret values are masked by config show and config get
+	secret bool
+	// network settings can also be set by the active network profile
+	network bool
+	// number settings are written to the config file as YAML numbers
+	number bool
+	// check validates a value before config set writes it
+	check func(string) error
+}
+
+var settings = []setting{
+	{key: "network", flag: "network", check: checkNetwork},
+	{key: "rpc_url", network: true},
+	{key: "chain_id", network: true, number: true, check: checkUint},
+	{key: "domain", network: true},
+	{key: "contracts.registrar", network: true, check: checkAddress},
+	{key: "contracts.registrar_controller", network: true, check: checkAddress},
+	{key: "contracts.registry", network: true, check: checkAddress},
+	{key: "contracts.l2_resolver", network: true, check: checkAddress},
+	{key: "contracts.reverse_registrar", network: true, check: checkAddress},
+	{key: "contracts.multicall3", network: true, check: checkAddress},
+	{key: "signer", check: checkSigner},
+	{key: "private_key", secret: true},
+	{key: "keystore.dir"},
+	{key: "keystore.account", check: checkAddress},
+	{key: "mnemonic", secret: true},
+	{key: "mnemonic_path", check: checkDerivationPath},
+	{key: "remote.url"},
+	{key: "remote.account", check: checkAddress},
+	{key: "gas.max_fee", flag: "max-fee", check: checkGwei},
+	{key: "gas.priority_fee", flag: "priority-fee", check: checkGwei},
+	{key: "gas.limit", flag: "gas-limit", number: true, check: checkUint},
+	{key: "gas.margin", flag: "gas-margin", number: true, check: checkUint},
+	{key: "confirmations", flag: "confirmations", number: true, check: checkUint},
+	{key: "receipt_timeout", flag: "receipt-timeout", check: checkDuration},
+	{key: "timeout", flag: "timeout", check: checkDuration},
+	{key: "output", flag: "output", check: checkOutput},
+}
+
+// configOutput is one setting with its effective value and where it came
+// from: flag, env, config, network (the active network profile) or default.
+type configOutput struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show or change the configuration",
+	Long: `Show or change the configuration.
+
+Settings are read from flags, BASENAMES_ environment variables and the config
+file, in that order of precedence. The environment variable of a setting is
+its key in upper case with dots replaced by underscores, for example
+BASENAMES_GAS_MAX_FEE for gas.max_fee.
+
+The rpc_url, chain_id, domain and contracts settings default to the active
+network profile, selected with --network. Custom profiles are defined under networks
+in the config file.`,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:         "show",
+	Short:       "Show the effective value and source of every setting",
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{annotationOffline: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outputs := make([]configOutput, 0, len(settings))
+		for _, s := range settings {
+			outputs = append(outputs, newConfigOutput(s))
+		}
+
+		return printResult(outputs, func() {
+			for _, output := range outputs {
+				if output.Value == "" {
+					fmt.Printf("%s: (unset)\n", output.Key)
+					continue
+				}
+				fmt.Printf("%s: %s (%s)\n", output.Key, output.Value, output.Source)
+			}
+		})
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:         "get <key>",
+	Short:       "Print the effective value of a setting",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationOffline: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := findSetting(args[0])
+		if err != nil {
+			return err
+		}
+
+		output := newConfigOutput(s)
+		return printResult(output, func() {
+			fmt.Println(output.Value)
+		})
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:         "set <key> <value>",
+	Short:       "Write a setting to the config file",
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{annotationOffline: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := findSetting(args[0])
+		if err != nil {
+			return err
+		}
+		value := args[1]
+		if s.check != nil {
+			if err := s.check(value); err != nil {
+				return invalidInputf("invalid %s: %v", s.key, err)
+			}
+		}
+
+		path, err := writeSetting(s, value)
+		if err != nil {
+			return err
+		}
+
+		output := configOutput{Key: s.key, Value: value, Source: "config"}
+		if s.secret {
+			output.Value = maskSecret(value)
+		}
+		return printResult(output, func() {
+			fmt.Printf("Set %s to %s in %s\n", output.Key, output.Value, path)
+		})
+	},
+}
+
+// bindSettings binds every setting to its environment variable and flag and
+// sets the defaults of settings without a flag. Network settings have no
+// default here; they fall back to the active network profile.
+func bindSettings() {
+	signer := base.DefaultConfig().Signer
+	viper.SetDefault("signer", signer.Type)
+	viper.SetDefault("keystore.dir", defaultKeystoreDir)
+	viper.SetDefault("mnemonic_path", signer.MnemonicPath)
+
+	for _, s := range settings {
+		cobra.CheckErr(viper.BindEnv(s.key, envName(s.key)))
+		if s.flag != "" {
+			cobra.CheckErr(viper.BindPFlag(s.key, rootCmd.PersistentFlags().Lookup(s.flag)))
+		}
+	}
+}
+
+// loadConfig builds the client configuration from the effective settings.
+func loadConfig() (base.Config, error) {
+	network, err := activeNetwork()
+	if err != nil {
+		return base.Config{}, err
+	}
+
+	signer, err := signerConfig()
+	if err != nil {
+		return base.Config{}, err
+	}
+
+	var r configReader
+	config := base.Config{
+		RpcURLs: network.RpcURLs,
+		Network: network,
+		Signer:  signer,
+		TxOptions: base.TxOptions{
+			MaxFee:           r.gwei("gas.max_fee"),
+			PriorityFee:      r.gwei("gas.priority_fee"),
+			GasLimit:         r.uint("gas.limit"),
+			GasMarginPercent: r.uint("gas.margin"),
+			Confirmations:    r.uint("confirmations"),
+			ReceiptTimeout:   r.duration("receipt_timeout"),
+		},
+	}
+	return config, r.err
+}
+
+// signerConfig reads the settings of the selected signer only, so that a
+// secret meant for another signer never reaches the client. A private key or
+// mnemonic left set for an unselected signer is an error rather than being
+// silently kept around.
+func signerConfig() (base.SignerConfig, error) {
+	signer := base.SignerConfig{Type: settingString("signer")}
+	if err := checkSigner(signer.Type); err != nil {
+		return base.SignerConfig{}, invalidInputf("invalid signer: %v", err)
+	}
+
+	secrets := []struct{ key, signer string }{
+		{"private_key", base.SignerPrivateKey},
+		{"mnemonic", base.SignerMnemonic},
+	}
+	for _, secret := range secrets {
+		if secret.signer != signer.Type && settingString(secret.key) != "" {
+			return base.SignerConfig{}, invalidInputf("%s is set but the signer is %s; unset %s and remove %s from the config file", secret.key, signer.Type, envName(secret.key), secret.key)
+		}
+	}
+
+	switch signer.Type {
+	case base.SignerPrivateKey:
+		signer.PrivateKey = settingString("private_key")
+	case base.SignerKeystore:
+		signer.KeystoreDir = keystoreDir()
+		signer.KeystoreAccount = settingString("keystore.account")
+		signer.Password = func(ctx context.Context) (string, error) {
+			return readPassword(ctx, "Keystore password", false)
+		}
+	case base.SignerMnemonic:
+		signer.Mnemonic = settingString("mnemonic")
+		signer.MnemonicPath = settingString("mnemonic_path")
+	case base.SignerRemote:
+		signer.RemoteURL = settingString("remote.url")
+		signer.RemoteAccount = settingString("remote.account")
+	}
+	return signer, nil
+}
+
+// configReader parses settings and keeps the first error.
+type configReader struct {
+	err error
+}
+
+func (r *configReader) uint(key string) uint64 {
+	value, err := parseUint(settingString(key))
+	r.fail(key, err)
+	return value
+}
+
+func (r *configReader) duration(key string) time.Duration {
+	value, err := time.ParseDuration(settingString(key))
+	r.fail(key, err)
+	return value
+}
+
+func (r *configReader) address(key string) common.Address {
+	value, err := parseAddress(settingString(key))
+	r.fail(key, err)
+	return value
+}
+
+func (r *configReader) gwei(key string) *big.Int {
+	value, err := parseGwei(settingString(key))
+	r.fail(key, err)
+	return value
+}
+
+func (r *configReader) fail(key string, err error) {
+	if err != nil && r.err == nil {
+		r.err = invalidInputf("invalid %s: %v", key, err)
+	}
+}
+
+// activeNetwork returns the network selected by the network setting. A
+// profile under networks in the config file defines a custom network or
+// overrides fields of the built-in one of the same name. The profile takes
+// precedence over top-level network settings in the config file, but not over
+// environment variables.
+func activeNetwork() (base.Network, error) {
+	name := settingString("network")
+	if err := checkNetwork(name); err != nil {
+		return base.Network{}, invalidInputf("invalid network: %v", err)
+	}
+	network, _ := base.LookupNetwork(name)
+	network.Name = name
+	profile := "networks." + name
+
+	var r configReader
+	applyNetworkSettings(&r, &network, "", viper.InConfig)
+	applyNetworkSettings(&r, &network, profile+".", viper.IsSet)
+	applyNetworkSettings(&r, &network, "", func(key string) bool {
+		return os.Getenv(envName(key)) != ""
+	})
+	if r.err != nil {
+		return base.Network{}, r.err
+	}
+	// Custom networks default to the canonical Multicall3, base.eth and the
+	// reverse namespace of their chain
+	if network.ReverseNamespace == "" {
+		network.ReverseNamespace = base.ChainReverseNamespace(network.ChainID)
+	}
+	if network.Contracts.Multicall3 == (common.Address{}) {
+		network.Contracts.Multicall3 = common.HexToAddress(base.Multicall3Address)
+	}
+	if network.Domain == "" {
+		network.Domain = base.BaseMainnet.Domain
+	}
+	if err := network.Validate(); err != nil {
+		return base.Network{}, invalidInputf("%v, set it under %s in the config file", err, profile)
+	}
+	return network, nil
+}
+
+// applyNetworkSettings overrides the fields of network with the settings
+// under prefix for which isSet is true.
+func applyNetworkSettings(r *configReader, network *base.Network, prefix string, isSet func(string) bool) {
+	if isSet(prefix + "rpc_url") {
+		network.RpcURLs = base.ParseRpcURLs(settingString(prefix + "rpc_url"))
+	}
+	if isSet(prefix + "chain_id") {
+		network.ChainID = r.uint(prefix + "chain_id")
+	}
+	if isSet(prefix + "domain") {
+		network.Domain = strings.ToLower(strings.Trim(settingString(prefix+"domain"), "."))
+	}
+	for _, contract := range contractSettings(&network.Contracts) {
+		if isSet(prefix + contract.key) {
+			*contract.address = r.address(prefix + contract.key)
+		}
+	}
+}
+
+type contractSetting struct {
+	key     string
+	address *common.Address
+}
+
+// contractSettings pairs the contracts settings with the fields they set.
+func contractSettings(contracts *base.Contracts) []contractSetting {
+	return []contractSetting{
+		{"contracts.registrar", &contracts.Registrar},
+		{"contracts.registrar_controller", &contracts.RegistrarController},
+		{"contracts.registry", &contracts.Registry},
+		{"contracts.l2_resolver", &contracts.L2Resolver},
+		{"contracts.reverse_registrar", &contracts.ReverseRegistrar},
+		{"contracts.multicall3", &contracts.Multicall3},
+	}
+}
+
+// networkValue returns the effective value of a network setting and its
+// source, or false when the network is invalid.
+func networkValue(key string) (value, source string, ok bool) {
+	network, err := activeNetwork()
+	if err != nil {
+		return "", "", false
+	}
+
+	switch key {
+	case "rpc_url":
+		value = strings.Join(network.RpcURLs, ",")
+	case "chain_id":
+		value = strconv.FormatUint(network.ChainID, 10)
+	case "domain":
+		value = network.Domain
+	}
+	for _, contract := range contractSettings(&network.Contracts) {
+		if contract.key == key && *contract.address != (common.Address{}) {
+			value = contract.address.Hex()
+		}
+	}
+
+	switch {
+	case value == "":
+		source = "default"
+	case os.Getenv(envName(key)) != "":
+		source = "env"
+	case viper.IsSet("networks." + network.Name + "." + key):
+		source = "network"
+	case viper.InConfig(key):
+		source = "config"
+	default:
+		source = "network"
+	}
+	return value, source, true
+}
+
+func findSetting(key string) (setting, error) {
+	for _, s := range settings {
+		if s.key == strings.ToLower(key) {
+			return s, nil
+		}
+	}
+	return setting{}, invalidInputf("unknown setting %q, run 'basenames config show' to list them", key)
+}
+
+func newConfigOutput(s setting) configOutput {
+	output := configOutput{Key: s.key, Value: settingString(s.key), Source: settingSource(s)}
+	if s.network {
+		if value, source, ok := networkValue(s.key); ok {
+			output.Value = value
+			output.Source = source
+		}
+	}
+	if s.secret {
+		output.Value = maskSecret(output.Value)
+	}
+	return output
+}
+
+// settingString returns the effective value of a setting as a string. Lists
+// in the config file are joined with commas.
+func settingString(key string) string {
+	switch value := viper.Get(key).(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		parts := make([]string, 0, len(value))
+		for _, part := range value {
+			parts = append(parts, fmt.Sprint(part))
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// settingSource reports where the effective value of a setting comes from,
+// following viper's order of precedence.
+func settingSource(s setting) string {
+	switch {
+	case s.flag != "" && rootCmd.PersistentFlags().Changed(s.flag):
+		return "flag"
+	case os.Getenv(envName(s.key)) != "":
+		return "env"
+	case viper.InConfig(s.key):
+		return "config"
+	}
+	return "default"
+}
+
+// keystoreDir returns the keystore.dir setting with a leading ~ expanded to
+// the home directory.
+func keystoreDir() string {
+	dir := settingString("keystore.dir")
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, dir[1:])
+		}
+	}
+	return dir
+}
+
+func envName(key string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+func maskSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "********"
+}
+
+// configFilePath returns the config file config set writes to: --config, the
+// file that was read, or ~/.basenames.yaml.
+func configFilePath() (string, error) {
+	if cfgFile != "" {
+		return cfgFile, nil
+	}
+	if used := viper.ConfigFileUsed(); used != "" {
+		return used, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".basenames.yaml"), nil
+}
+
+// writeSetting stores a setting in the config file and returns the file's
+// path. Number settings are written as YAML numbers and every other setting as
+// a string. Only the file's own content is rewritten, so values coming from
+// flags or the environment are never persisted. The file may hold a private
+// key, so it is made readable by its owner only.
+func writeSetting(s setting, value string) (string, error) {
+	path, err := configFilePath()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config file: %w", err)
+	}
+
+	file := viper.New()
+	file.SetConfigFile(path)
+	if filepath.Ext(path) == "" {
+		file.SetConfigType("yaml")
+	}
+	file.SetConfigPermissions(0o600)
+	if err := file.ReadInConfig(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if s.number {
+		number, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return "", invalidInputf("invalid %s: %v", s.key, err)
+		}
+		file.Set(s.key, number)
+	} else {
+		file.Set(s.key, value)
+	}
+	if err := file.WriteConfigAs(path); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	// The permissions above only apply when the file is created
+	if err := os.Chmod(path, 0o600); err != nil {
+		return "", fmt.Errorf("failed to restrict the permissions of %s: %w", path, err)
+	}
+	return path, nil
+}
+
+func parseUint(value string) (uint64, error) {
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func parseAddress(value string) (common.Address, error) {
+	if !common.IsHexAddress(value) {
+		return common.Address{}, fmt.Errorf("%q is not a hex address", value)
+	}
+	return common.HexToAddress(value), nil
+}
+
+// parseGwei parses a fee in gwei. An empty value leaves the fee to be derived
+// from the chain.
+func parseGwei(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	return base.GweiToWei(value)
+}
+
+func checkUint(value string) error {
+	_, err := parseUint(value)
+	return err
+}
+
+func checkAddress(value string) error {
+	_, err := parseAddress(value)
+	return err
+}
+
+func checkGwei(value string) error {
+	_, err := parseGwei(value)
+	return err
+}
+
+func checkDuration(value string) error {
+	_, err := time.ParseDuration(value)
+	return err
+}
+
+func checkSigner(value string) error {
+	for _, signer := range base.SignerTypes() {
+		if value == signer {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown signer %q, expected one of %s", value, strings.Join(base.SignerTypes(), ", "))
+}
+
+func checkDerivationPath(value string) error {
+	if _, err := accounts.ParseDerivationPath(value); err != nil {
+		return fmt.Errorf("invalid derivation path %q: %v", value, err)
+	}
+	return nil
+}
+
+func checkNetwork(value string) error {
+	if _, ok := base.LookupNetwork(value); ok || viper.IsSet("networks."+value) {
+		return nil
+	}
+	return fmt.Errorf("unknown network %q, expected %s or a profile under networks in the config file", value, strings.Join(base.NetworkNames(), ", "))
+}
+
+func checkOutput(value string) error {
+	switch value {
+	case outputText, outputJSON, outputYAML, outputCSV:
+		return nil
+	}
+	return fmt.Errorf("unknown format %q, expected text, json, yaml or csv", value)
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+}
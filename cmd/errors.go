@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hughescoin/basenames-cli/base"
+)
+
+// Exit codes returned by the CLI. Scripts rely on these, so existing values
+// must not change.
+const (
+	exitOK                = 0
+	exitFailure           = 1
+	exitInvalidInput      = 2
+	exitRPC               = 3
+	exitReverted          = 4
+	exitNotFound          = 5
+	exitInsufficientFunds = 6
+	exitUnexpectedState   = 7
+	exitInterrupted       = 130
+)
+
+// errCancelled is returned when a confirmation prompt is declined.
+var errCancelled = errors.New("cancelled by user")
+
+// exitCode maps an error returned by a command to the process exit code.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case errors.Is(err, context.DeadlineExceeded):
+		return exitRPC
+	}
+
+	switch base.KindOf(err) {
+	case base.KindInvalidInput:
+		return exitInvalidInput
+	case base.KindRPC:
+		return exitRPC
+	case base.KindReverted:
+		return exitReverted
+	case base.KindNotFound:
+		return exitNotFound
+	case base.KindInsufficientFunds:
+		return exitInsufficientFunds
+	case base.KindUnexpectedState:
+		return exitUnexpectedState
+	}
+	return exitFailure
+}
+
+// errorKind names the category of err in structured error output.
+func errorKind(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "interrupted"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	}
+	return base.KindOf(err).String()
+}
+
+func invalidInputf(format string, args ...interface{}) error {
+	return base.Errorf(base.KindInvalidInput, format, args...)
+}
+
+func notFoundf(format string, args ...interface{}) error {
+	return base.Errorf(base.KindNotFound, format, args...)
+}
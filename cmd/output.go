@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats accepted by the --output flag.
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+	outputCSV  = "csv"
+)
+
+var outputFormat = outputText
+
+func validateOutputFormat() error {
+	if err := checkOutput(outputFormat); err != nil {
+		return invalidInputf("invalid output: %v", err)
+	}
+	return nil
+}
+
+// printResult writes a command's result to stdout. In text mode the text
+// function prints the human-readable form; every other format serializes
+// result, whose json tags define the schema.
+func printResult(result interface{}, text func()) error {
+	if outputFormat == outputText {
+		text()
+		return nil
+	}
+	if err := writeResult(os.Stdout, outputFormat, result); err != nil {
+		return fmt.Errorf("failed to write %s output: %v", outputFormat, err)
+	}
+	return nil
+}
+
+// errorOutput is the structured form of an error written to stderr.
+type errorOutput struct {
+	Error string `json:"error"`
+	Kind  string `json:"kind"`
+	Code  int    `json:"code"`
+}
+
+// printError writes err to stderr, as an object when a structured output
+// format is selected. CSV output reports errors as JSON.
+func printError(err error) {
+	if outputFormat == outputText {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+
+	format := outputFormat
+	if format == outputCSV {
+		format = outputJSON
+	}
+	output := errorOutput{Error: err.Error(), Kind: errorKind(err), Code: exitCode(err)}
+	if writeErr := writeResult(os.Stderr, format, output); writeErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+}
+
+// logf prints progress messages to stderr so stdout only carries results.
+func logf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format, args...)
+}
+
+func writeResult(w io.Writer, format string, result interface{}) error {
+	switch format {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case outputYAML:
+		return writeYAML(w, result)
+	case outputCSV:
+		return writeCSV(w, result)
+	}
+	return fmt.Errorf("unsupported output format %q", format)
+}
+
+// writeYAML encodes result through its JSON form so that YAML output uses the
+// same field names and order as JSON.
+func writeYAML(w io.Writer, result interface{}) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// blockStyle clears the flow and quoting styles inherited from JSON.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// writeCSV writes a struct or a slice of structs as CSV with a header row
+// taken from the json tags. Nested values are written as JSON.
+func writeCSV(w io.Writer, result interface{}) error {
+	value := reflect.Indirect(reflect.ValueOf(result))
+
+	var rows []reflect.Value
+	rowType := value.Type()
+	if value.Kind() == reflect.Slice {
+		rowType = rowType.Elem()
+		for i := 0; i < value.Len(); i++ {
+			rows = append(rows, reflect.Indirect(value.Index(i)))
+		}
+	} else {
+		rows = append(rows, value)
+	}
+	if rowType.Kind() == reflect.Ptr {
+		rowType = rowType.Elem()
+	}
+	if rowType.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot write %s as CSV", rowType)
+	}
+
+	var header []string
+	var fields []int
+	for i := 0; i < rowType.NumField(); i++ {
+		name, _, _ := strings.Cut(rowType.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		header = append(header, name)
+		fields = append(fields, i)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := make([]string, len(fields))
+		for i, field := range fields {
+			cell, err := csvCell(row.Field(field).Interface())
+			if err != nil {
+				return err
+			}
+			record[i] = cell
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func csvCell(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	if bytes.Equal(data, []byte("null")) {
+		return "", nil
+	}
+
+	var text string
+	if json.Unmarshal(data, &text) == nil {
+		return text, nil
+	}
+	return string(data), nil
+}
+
+// formatTime renders timestamps in outputs as RFC 3339 in UTC.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
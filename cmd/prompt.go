@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+var (
+	// passwordFD is set with --password-fd; -1 prompts on the terminal
+	passwordFD = -1
+	// fdPassword caches the password read from passwordFD, which can only
+	// be read once
+	fdPassword *string
+)
+
+// confirm asks a yes/no question on stdin. It returns nil without asking
+// when the --yes flag is set, errCancelled if the answer is not yes, and the
+// context's error if ctx is cancelled while waiting.
+func confirm(ctx context.Context, question string) error {
+	if assumeYes {
+		return nil
+	}
+
+	// Prompt on stderr so stdout only carries command output
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+
+	answers := make(chan string, 1)
+	go func() {
+		answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			answer = ""
+		}
+		answers <- answer
+	}()
+
+	select {
+	case <-ctx.Done():
+		fmt.Fprintln(os.Stderr)
+		return ctx.Err()
+	case answer := <-answers:
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			return errCancelled
+		}
+		return nil
+	}
+}
+
+// readPassword returns the password read from --password-fd, or prompts for
+// it on the terminal without echo. With repeat set, an interactive password
+// is asked twice and must match, for new keys.
+func readPassword(ctx context.Context, prompt string, repeat bool) (string, error) {
+	if passwordFD >= 0 {
+		return readPasswordFD(ctx)
+	}
+
+	password, err := promptPassword(ctx, prompt)
+	if err != nil || !repeat {
+		return password, err
+	}
+	again, err := promptPassword(ctx, "Repeat password")
+	if err != nil {
+		return "", err
+	}
+	if again != password {
+		return "", invalidInputf("passwords do not match")
+	}
+	return password, nil
+}
+
+// readPasswordFD reads the first line of --password-fd. The writer may keep
+// the descriptor open, so it does not wait for EOF, and gives up when ctx is
+// cancelled.
+func readPasswordFD(ctx context.Context) (string, error) {
+	if fdPassword != nil {
+		return *fdPassword, nil
+	}
+
+	file := os.NewFile(uintptr(passwordFD), "password")
+	if file == nil {
+		return "", invalidInputf("invalid --password-fd %d", passwordFD)
+	}
+	// Closing the file also unblocks the read below when ctx is cancelled
+	defer file.Close()
+
+	type result struct {
+		line string
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		line, err := bufio.NewReader(file).ReadString('\n')
+		results <- result{line, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", fmt.Errorf("failed to read the password from --password-fd %d: %w", passwordFD, ctx.Err())
+	case result := <-results:
+		if result.err != nil && result.err != io.EOF {
+			return "", invalidInputf("failed to read the password from --password-fd %d: %v", passwordFD, result.err)
+		}
+		password := strings.TrimSuffix(strings.TrimSuffix(result.line, "\n"), "\r")
+		fdPassword = &password
+		return password, nil
+	}
+}
+
+// promptPassword reads a password from the terminal without echo. The
+// terminal is restored if ctx is cancelled while waiting.
+func promptPassword(ctx context.Context, prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", invalidInputf("stdin is not a terminal, pass the password with --password-fd")
+	}
+	state, err := term.GetState(fd)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the terminal state: %w", err)
+	}
+
+	// Prompt on stderr so stdout only carries command output
+	fmt.Fprintf(os.Stderr, "%s: ", prompt)
+
+	type result struct {
+		password []byte
+		err      error
+	}
+	results := make(chan result, 1)
+	go func() {
+		password, err := term.ReadPassword(fd)
+		results <- result{password, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		term.Restore(fd, state)
+		fmt.Fprintln(os.Stderr)
+		return "", ctx.Err()
+	case result := <-results:
+		fmt.Fprintln(os.Stderr)
+		if result.err != nil {
+			return "", fmt.Errorf("failed to read the password: %w", result.err)
+		}
+		return string(result.password), nil
+	}
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hughescoin/basenames-cli/base"
+	"github.com/spf13/cobra"
+)
+
+type reclaimOutput struct {
+	Name          string    `json:"name"`
+	TokenId       string    `json:"tokenId"`
+	Owner         string    `json:"owner"`
+	RegistryOwner string    `json:"registryOwner"`
+	Transaction   *txOutput `json:"transaction"`
+}
+
+var reclaimCmd = &cobra.Command{
+	Use:         "reclaim <name>",
+	Short:       "Sync registry ownership of a basename with its NFT owner",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationSigner: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		basename, err := base.BaseClient.ParseBasename(args[0])
+		if err != nil {
+			return err
+		}
+		node, err := basename.Node()
+		if err != nil {
+			return err
+		}
+
+		nftOwner, err := base.BaseClient.OwnerOf(ctx, basename.TokenId)
+		if err != nil {
+			return fmt.Errorf("failed to check owner: %w", err)
+		}
+		registryOwner, err := base.BaseClient.RegistryOwner(ctx, node)
+		if err != nil {
+			return fmt.Errorf("failed to check registry owner: %w", err)
+		}
+
+		output := reclaimOutput{
+			Name:          basename.Name(),
+			TokenId:       basename.TokenId.String(),
+			Owner:         nftOwner.Hex(),
+			RegistryOwner: registryOwner.Hex(),
+		}
+		if registryOwner == nftOwner {
+			return printResult(output, func() {
+				fmt.Printf("NFT owner of %s: %s\n", basename.Describe(), nftOwner.Hex())
+				fmt.Printf("Registry owner: %s\n", registryOwner.Hex())
+				fmt.Println("Registry ownership is already in sync")
+			})
+		}
+		logf("NFT owner of %s: %s\n", basename.Describe(), nftOwner.Hex())
+		logf("Registry owner: %s\n", registryOwner.Hex())
+
+		signer := common.HexToAddress(base.BaseClient.Address)
+		if signer != nftOwner {
+			return invalidInputf("only the NFT owner %s can reclaim %s, the signer is %s", nftOwner.Hex(), basename.Name(), signer.Hex())
+		}
+
+		if err := confirm(ctx, fmt.Sprintf("Set registry owner to %s?", nftOwner.Hex())); err != nil {
+			return err
+		}
+
+		result, err := base.BaseClient.Reclaim(ctx, basename.TokenId, nftOwner)
+		if err != nil {
+			return fmt.Errorf("failed to send reclaim: %w", err)
+		}
+
+		registryOwner, err = base.BaseClient.RegistryOwner(ctx, node)
+		if err != nil {
+			return fmt.Errorf("failed to check registry owner: %w", err)
+		}
+		if registryOwner != nftOwner {
+			return base.Errorf(base.KindUnexpectedState, "reclaim was mined but the registry owner of %s is %s, expected %s", basename.Name(), registryOwner.Hex(), nftOwner.Hex())
+		}
+
+		output.RegistryOwner = registryOwner.Hex()
+		output.Transaction = newTxOutput(result)
+		return printResult(output, func() {
+			printTxResult(result)
+			fmt.Printf("Registry owner of %s set to %s\n", basename.Name(), nftOwner.Hex())
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reclaimCmd)
+}
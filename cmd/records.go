@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hughescoin/basenames-cli/base"
+	"github.com/spf13/cobra"
+)
+
+type recordOutput struct {
+	Name  string `json:"name"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type recordsSetOutput struct {
+	Name        string         `json:"name"`
+	Records     []recordOutput `json:"records"`
+	Transaction *txOutput      `json:"transaction"`
+}
+
+var recordsCmd = &cobra.Command{
+	Use:   "records",
+	Short: "Read or write a basename's text records",
+}
+
+var recordsGetCmd = &cobra.Command{
+	Use:   "get <name> [key...]",
+	Short: "Read text records (all well-known keys by default)",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		basename, err := base.BaseClient.ParseBasename(args[0])
+		if err != nil {
+			return err
+		}
+
+		keys := args[1:]
+		if len(keys) == 0 {
+			keys = base.TextRecordKeys
+		}
+
+		records, err := base.BaseClient.GetTextRecords(ctx, basename, keys)
+		if err != nil {
+			return fmt.Errorf("failed to read records for %s: %w", basename, err)
+		}
+
+		return printResult(newRecordOutputs(basename, records), func() {
+			fmt.Printf("Text records for %s:\n", basename.Name())
+			for _, record := range records {
+				fmt.Printf("  %s: %s\n", record.Key, record.Value)
+			}
+		})
+	},
+}
+
+var recordsSetCmd = &cobra.Command{
+	Use:         "set <name> key=value [key=value...]",
+	Short:       "Write text records in a single transaction",
+	Args:        cobra.MinimumNArgs(2),
+	Annotations: map[string]string{annotationSigner: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		basename, err := base.BaseClient.ParseBasename(args[0])
+		if err != nil {
+			return err
+		}
+
+		records := make([]base.TextRecord, 0, len(args)-1)
+		for _, arg := range args[1:] {
+			key, value, found := strings.Cut(arg, "=")
+			if !found || key == "" {
+				return invalidInputf("invalid record %q, expected key=value", arg)
+			}
+			records = append(records, base.TextRecord{Key: key, Value: value})
+		}
+
+		logf("Setting text records for %s:\n", basename.Name())
+		for _, record := range records {
+			logf("  %s: %s\n", record.Key, record.Value)
+		}
+		if err := confirm(ctx, "Proceed?"); err != nil {
+			return err
+		}
+
+		result, err := base.BaseClient.SetTextRecords(ctx, basename, records)
+		if err != nil {
+			return fmt.Errorf("failed to send update: %w", err)
+		}
+
+		output := recordsSetOutput{
+			Name:        basename.Name(),
+			Records:     newRecordOutputs(basename, records),
+			Transaction: newTxOutput(result),
+		}
+		return printResult(output, func() {
+			printTxResult(result)
+			fmt.Printf("Updated %d record(s) for %s\n", len(records), basename.Name())
+		})
+	},
+}
+
+func newRecordOutputs(basename *base.Basename, records []base.TextRecord) []recordOutput {
+	outputs := make([]recordOutput, 0, len(records))
+	for _, record := range records {
+		outputs = append(outputs, recordOutput{Name: basename.Name(), Key: record.Key, Value: record.Value})
+	}
+	return outputs
+}
+
+func init() {
+	rootCmd.AddCommand(recordsCmd)
+	recordsCmd.AddCommand(recordsGetCmd)
+	recordsCmd.AddCommand(recordsSetCmd)
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hughescoin/basenames-cli/base"
+	"github.com/spf13/cobra"
+)
+
+var (
+	registerYears   int64
+	registerOwner   string
+	registerPrimary bool
+)
+
+type registerOutput struct {
+	Name        string    `json:"name"`
+	TokenId     string    `json:"tokenId"`
+	Owner       string    `json:"owner"`
+	Expires     string    `json:"expires"`
+	Years       int64     `json:"years"`
+	Price       string    `json:"price"`
+	Transaction *txOutput `json:"transaction"`
+}
+
+var registerCmd = &cobra.Command{
+	Use:         "register <name>",
+	Short:       "Register an available basename",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationSigner: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		if registerYears < 1 {
+			return invalidInputf("--years must be at least 1")
+		}
+
+		basename, err := base.BaseClient.ParseBasename(args[0])
+		if err != nil {
+			return err
+		}
+		if basename.Label == "" {
+			return invalidInputf("register requires a name, not a token ID")
+		}
+
+		owner := common.HexToAddress(base.BaseClient.Address)
+		if registerOwner != "" {
+			if !common.IsHexAddress(registerOwner) {
+				return invalidInputf("invalid owner address %q", registerOwner)
+			}
+			owner = common.HexToAddress(registerOwner)
+		}
+		// The reverse record is set for the signer, so the name would be the
+		// primary name of an address that does not own it
+		if registerPrimary && owner != common.HexToAddress(base.BaseClient.Address) {
+			return invalidInputf("--primary cannot be used with --owner %s, the primary name is set for the signer %s", owner.Hex(), base.BaseClient.Address)
+		}
+
+		available, err := base.BaseClient.IsNameAvailable(ctx, basename.Label)
+		if err != nil {
+			return fmt.Errorf("failed to check availability: %w", err)
+		}
+		if !available {
+			return notFoundf("%s is not available", basename.Describe())
+		}
+
+		duration := base.YearsToDuration(registerYears)
+		price, err := base.BaseClient.RegisterPrice(ctx, basename.Label, duration)
+		if err != nil {
+			return fmt.Errorf("failed to get registration price: %w", err)
+		}
+
+		logf("Registering %s for %d year(s) to %s\n", basename.Describe(), registerYears, owner.Hex())
+		logf("Price: %s ETH\n", base.WeiToEth(price))
+		if err := confirm(ctx, "Proceed with registration?"); err != nil {
+			return err
+		}
+
+		request, err := base.BaseClient.NewRegisterRequest(basename, owner, duration, registerPrimary)
+		if err != nil {
+			return fmt.Errorf("failed to build registration: %w", err)
+		}
+
+		result, err := base.BaseClient.Register(ctx, request, price)
+		if err != nil {
+			return fmt.Errorf("failed to send registration: %w", err)
+		}
+
+		newOwner, err := base.BaseClient.OwnerOf(ctx, basename.TokenId)
+		if err != nil {
+			return fmt.Errorf("failed to check owner: %w", err)
+		}
+		expires, err := base.BaseClient.NameExpires(ctx, basename.TokenId)
+		if err != nil {
+			return fmt.Errorf("failed to check expiration: %w", err)
+		}
+
+		output := registerOutput{
+			Name:        basename.Name(),
+			TokenId:     basename.TokenId.String(),
+			Owner:       newOwner.Hex(),
+			Expires:     formatTime(expires),
+			Years:       registerYears,
+			Price:       price.String(),
+			Transaction: newTxOutput(result),
+		}
+		return printResult(output, func() {
+			printTxResult(result)
+			fmt.Printf("Registered %s\n", basename.Name())
+			fmt.Printf("Token ID: %s\n", basename.TokenId)
+			fmt.Printf("Owner: %s\n", newOwner.Hex())
+			fmt.Printf("Expires: %s\n", expires.Format(time.RFC3339))
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(registerCmd)
+
+	registerCmd.Flags().Int64Var(&registerYears, "years", 1, "Number of years to register the name for")
+	registerCmd.Flags().StringVar(&registerOwner, "owner", "", "Address to register the name to (default is the signer)")
+	registerCmd.Flags().BoolVar(&registerPrimary, "primary", false, "Also set the name as the signer's primary name")
+}
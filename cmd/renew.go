@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hughescoin/basenames-cli/base"
+	"github.com/spf13/cobra"
+)
+
+var renewYears int64
+
+type renewOutput struct {
+	Name        string    `json:"name"`
+	TokenId     string    `json:"tokenId"`
+	OldExpires  string    `json:"oldExpires"`
+	Expires     string    `json:"expires"`
+	Years       int64     `json:"years"`
+	Price       string    `json:"price"`
+	Transaction *txOutput `json:"transaction"`
+}
+
+var renewCmd = &cobra.Command{
+	Use:         "renew <name>",
+	Short:       "Renew a basename's registration",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationSigner: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		if renewYears < 1 {
+			return invalidInputf("--years must be at least 1")
+		}
+
+		basename, err := base.BaseClient.ParseBasename(args[0])
+		if err != nil {
+			return err
+		}
+		if basename.Label == "" {
+			return invalidInputf("renew requires a name, not a token ID")
+		}
+
+		oldExpiry, err := base.BaseClient.NameExpires(ctx, basename.TokenId)
+		if err != nil {
+			return fmt.Errorf("failed to check expiration: %w", err)
+		}
+
+		duration := base.YearsToDuration(renewYears)
+		price, err := base.BaseClient.RentPrice(ctx, basename.Label, duration)
+		if err != nil {
+			return fmt.Errorf("failed to get renewal price: %w", err)
+		}
+
+		logf("Renewing %s for %d year(s)\n", basename.Describe(), renewYears)
+		logf("Current expiration: %s\n", oldExpiry.Format(time.RFC3339))
+		logf("Price: %s ETH\n", base.WeiToEth(price))
+		if err := confirm(ctx, "Proceed with renewal?"); err != nil {
+			return err
+		}
+
+		result, err := base.BaseClient.Renew(ctx, basename.Label, duration, price)
+		if err != nil {
+			return fmt.Errorf("failed to send renewal: %w", err)
+		}
+
+		newExpiry, err := base.BaseClient.NameExpires(ctx, basename.TokenId)
+		if err != nil {
+			return fmt.Errorf("failed to check expiration: %w", err)
+		}
+
+		output := renewOutput{
+			Name:        basename.Name(),
+			TokenId:     basename.TokenId.String(),
+			OldExpires:  formatTime(oldExpiry),
+			Expires:     formatTime(newExpiry),
+			Years:       renewYears,
+			Price:       price.String(),
+			Transaction: newTxOutput(result),
+		}
+		return printResult(output, func() {
+			printTxResult(result)
+			fmt.Printf("Old expiration: %s\n", oldExpiry.Format(time.RFC3339))
+			fmt.Printf("New expiration: %s\n", newExpiry.Format(time.RFC3339))
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(renewCmd)
+
+	renewCmd.Flags().Int64Var(&renewYears, "years", 1, "Number of years to extend the registration by")
+}
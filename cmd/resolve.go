@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hughescoin/basenames-cli/base"
+	"github.com/spf13/cobra"
+)
+
+type resolveOutput struct {
+	Name     string `json:"name"`
+	TokenId  string `json:"tokenId"`
+	Address  string `json:"address"`
+	Resolver string `json:"resolver"`
+}
+
+var resolveCmd = &cobra.Command{
+	Use:   "resolve <name>",
+	Short: "Resolve a basename to its address record",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		basename, err := base.BaseClient.ParseBasename(args[0])
+		if err != nil {
+			return err
+		}
+
+		address, resolver, err := base.BaseClient.Resolve(ctx, basename)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", basename, err)
+		}
+
+		output := resolveOutput{Name: basename.Name(), TokenId: basename.TokenId.String(), Resolver: resolver.Hex()}
+		if address != (common.Address{}) {
+			output.Address = address.Hex()
+		}
+		return printResult(output, func() {
+			if address == (common.Address{}) {
+				fmt.Printf("%s has no address record (resolver %s)\n", basename.Name(), resolver.Hex())
+				return
+			}
+			fmt.Printf("%s resolves to %s (resolver %s)\n", basename.Name(), address.Hex(), resolver.Hex())
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(resolveCmd)
+}
@@ -4,36 +4,221 @@ Copyright © 2024 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hughescoin/basenames-cli/base"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-var cfgFile string
+// annotationSigner marks commands that send transactions. Only these load
+// the signer; every other command only needs an RPC URL.
+const annotationSigner = "signer"
+
+// greetingTimeout bounds the primary name lookup done when a signer is loaded.
+const greetingTimeout = 5 * time.Second
+
+var (
+	cfgFile      string
+	configErr    error
+	assumeYes    bool
+	watchAddress string
+
+	timeout       time.Duration
+	cancelTimeout context.CancelFunc = func() {}
+
+	// commandStarted is set once cobra has parsed flags and validated
+	// arguments. Errors returned before that are usage errors.
+	commandStarted bool
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "basenames",
 	Short: "A CLI for managing basenames on the blockchain",
+	// Errors are printed by Execute, to stderr and in the selected format
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Cobra checks required flags after this hook; check them first so
+		// they are reported as usage errors too
+		if err := cmd.ValidateRequiredFlags(); err != nil {
+			return err
+		}
+		if err := cmd.ValidateFlagGroups(); err != nil {
+			return err
+		}
+		commandStarted = true
+		// Help and shell completion must keep working with a broken
+		// configuration, so that it can be diagnosed
+		if isBuiltinCommand(cmd) {
+			return nil
+		}
+		if configErr != nil {
+			return configErr
+		}
+
+		// Flags, environment variables and the config file all feed these
+		outputFormat = settingString("output")
+		if err := validateOutputFormat(); err != nil {
+			return err
+		}
+		var r configReader
+		timeout = r.duration("timeout")
+		if r.err != nil {
+			return r.err
+		}
+		if timeout > 0 {
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			cancelTimeout = cancel
+			cmd.SetContext(ctx)
+		}
+
+		if _, offline := cmd.Annotations[annotationOffline]; offline {
+			return nil
+		}
+		return initClient(cmd)
+	},
+}
+
+// isBuiltinCommand reports whether cmd is one of cobra's help and shell
+// completion commands, or one of their subcommands.
+func isBuiltinCommand(cmd *cobra.Command) bool {
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		// Only direct children of the root command
+		if c.Parent().HasParent() {
+			continue
+		}
+		switch c.Name() {
+		case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			return true
+		}
+	}
+	return false
 }
 
 func Execute() {
-	err := rootCmd.Execute()
-	if err != nil {
-		os.Exit(1)
+	// Cancel in-flight RPC calls on Ctrl-C or SIGTERM. A second signal
+	// falls back to the default behavior and kills the process.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	cancelTimeout()
+	stop()
+	if base.BaseClient != nil {
+		base.BaseClient.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	if !commandStarted && base.KindOf(err) == base.KindUnknown {
+		err = &base.Error{Kind: base.KindInvalidInput, Err: err}
+	}
+	printError(err)
+	if !commandStarted && outputFormat == outputText {
+		fmt.Fprintf(os.Stderr, "Run '%s --help' for usage.\n", cmd.CommandPath())
 	}
+	os.Exit(exitCode(err))
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.basenames.yaml)")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "maximum run time for the command, e.g. 30s (default is no limit)")
+	rootCmd.PersistentFlags().String("network", base.BaseMainnet.Name, fmt.Sprintf("network profile: %s or a profile from the config file", strings.Join(base.NetworkNames(), ", ")))
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputText, "output format: text, json, yaml or csv")
+	rootCmd.PersistentFlags().StringVar(&watchAddress, "address", "", "address or basename to query as, without a private key (read-only commands)")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "skip confirmation prompts")
+	rootCmd.PersistentFlags().IntVar(&passwordFD, "password-fd", -1, "read the keystore password from this file descriptor instead of the terminal")
+	rootCmd.PersistentFlags().String("max-fee", "", "max fee per gas in gwei (default is 2x base fee plus priority fee)")
+	rootCmd.PersistentFlags().String("priority-fee", "", "max priority fee per gas in gwei (default is the node's suggestion)")
+	rootCmd.PersistentFlags().Uint64("gas-limit", 0, "gas limit for transactions (default is estimated)")
+	rootCmd.PersistentFlags().Uint64("gas-margin", base.DefaultGasMarginPercent, "percentage added to estimated gas limits")
+	rootCmd.PersistentFlags().Uint64("confirmations", base.DefaultConfirmations, "number of confirmations to wait for after a transaction is mined")
+	rootCmd.PersistentFlags().Duration("receipt-timeout", base.DefaultReceiptTimeout, "how long to wait for a transaction receipt")
+	bindSettings()
 	rootCmd.AddCommand(checkCmd)
 }
 
+// initClient creates the shared client from the effective configuration.
+// Endpoints are dialed on first use, so commands that never touch the chain
+// work without an RPC URL. The signer is only loaded for commands annotated
+// as signers.
+func initClient(cmd *cobra.Command) error {
+	if base.BaseClient == nil {
+		config, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		base.InitClient(config)
+	}
+
+	if _, signs := cmd.Annotations[annotationSigner]; signs {
+		if watchAddress != "" {
+			return invalidInputf("--address is watch-only and cannot be used with %s", cmd.CommandPath())
+		}
+		if err := base.BaseClient.LoadSigner(cmd.Context()); err != nil {
+			return err
+		}
+		greetSigner(cmd.Context())
+	}
+	return nil
+}
+
+// greetSigner tells the user which account will sign, by its primary
+// basename when one is set.
+func greetSigner(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, greetingTimeout)
+	defer cancel()
+
+	greeting := base.BaseClient.Address
+	if name, err := base.BaseClient.ReverseResolve(ctx, common.HexToAddress(base.BaseClient.Address)); err == nil {
+		greeting = name
+	}
+	logf("Signing as %s\n", greeting)
+}
+
+// accountAddress returns the account read-only commands act on: --address,
+// or the signer's address when a signer is configured. --address is resolved
+// here, on first use, so commands that take no account never look it up.
+func accountAddress(ctx context.Context) (string, error) {
+	if base.BaseClient.Address != "" {
+		return base.BaseClient.Address, nil
+	}
+	if watchAddress != "" {
+		address, err := base.BaseClient.ResolveAddress(ctx, watchAddress)
+		if err != nil {
+			return "", fmt.Errorf("invalid --address: %w", err)
+		}
+		base.BaseClient.WatchAddress(address)
+		return base.BaseClient.Address, nil
+	}
+	if err := base.BaseClient.LoadSigner(ctx); err != nil {
+		return "", invalidInputf("--address or a signer is required: %v", err)
+	}
+	return base.BaseClient.Address, nil
+}
+
 func initConfig() {
 	if cfgFile != "" {
 		viper.SetConfigFile(cfgFile)
+		if filepath.Ext(cfgFile) == "" {
+			viper.SetConfigType("yaml")
+		}
 	} else {
 		home, err := os.UserHomeDir()
 		cobra.CheckErr(err)
@@ -43,9 +228,13 @@ func initConfig() {
 		viper.SetConfigName(".basenames")
 	}
 
-	viper.AutomaticEnv()
-
-	if err := viper.ReadInConfig(); err == nil {
+	// A missing file is fine, config set creates it
+	err := viper.ReadInConfig()
+	var notFound viper.ConfigFileNotFoundError
+	switch {
+	case err == nil:
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+	case !errors.As(err, &notFound) && !errors.Is(err, fs.ErrNotExist):
+		configErr = invalidInputf("failed to read config file: %v", err)
 	}
 }
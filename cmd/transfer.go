@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hughescoin/basenames-cli/base"
+	"github.com/spf13/cobra"
+)
+
+var transferTo string
+
+type transferOutput struct {
+	Name        string    `json:"name"`
+	TokenId     string    `json:"tokenId"`
+	From        string    `json:"from"`
+	Owner       string    `json:"owner"`
+	Transaction *txOutput `json:"transaction"`
+}
+
+var transferCmd = &cobra.Command{
+	Use:         "transfer <name>",
+	Short:       "Transfer a basename to another address or basename",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationSigner: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		basename, err := base.BaseClient.ParseBasename(args[0])
+		if err != nil {
+			return err
+		}
+
+		recipient, err := base.BaseClient.ResolveAddress(ctx, transferTo)
+		if err != nil {
+			return err
+		}
+
+		// Only the current owner can transfer the token
+		signer := common.HexToAddress(base.BaseClient.Address)
+		owner, err := base.BaseClient.OwnerOf(ctx, basename.TokenId)
+		if err != nil {
+			return fmt.Errorf("failed to check owner: %w", err)
+		}
+		if owner != signer {
+			return invalidInputf("%s is owned by %s, not the signer %s", basename.Describe(), owner.Hex(), signer.Hex())
+		}
+		if recipient == owner {
+			return invalidInputf("%s already owns %s", recipient.Hex(), basename.Describe())
+		}
+
+		isContract, err := base.BaseClient.IsContract(ctx, recipient)
+		if err != nil {
+			return fmt.Errorf("failed to check recipient: %w", err)
+		}
+		if isContract {
+			logf("Warning: %s is a contract. The transfer will revert unless it implements onERC721Received.\n", recipient.Hex())
+		}
+
+		logf("Transferring %s from %s to %s\n", basename.Describe(), owner.Hex(), recipient.Hex())
+		if err := confirm(ctx, "Proceed with transfer?"); err != nil {
+			return err
+		}
+
+		result, err := base.BaseClient.SafeTransfer(ctx, owner, recipient, basename.TokenId)
+		if err != nil {
+			return fmt.Errorf("failed to send transfer: %w", err)
+		}
+
+		newOwner, err := base.BaseClient.OwnerOf(ctx, basename.TokenId)
+		if err != nil {
+			return fmt.Errorf("failed to check owner: %w", err)
+		}
+		if newOwner != recipient {
+			return base.Errorf(base.KindUnexpectedState, "transfer was mined but the owner of %s is %s, expected %s", basename.Describe(), newOwner.Hex(), recipient.Hex())
+		}
+
+		output := transferOutput{
+			Name:        basename.Name(),
+			TokenId:     basename.TokenId.String(),
+			From:        owner.Hex(),
+			Owner:       newOwner.Hex(),
+			Transaction: newTxOutput(result),
+		}
+		return printResult(output, func() {
+			printTxResult(result)
+			fmt.Printf("New owner of %s: %s\n", basename.Describe(), newOwner.Hex())
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(transferCmd)
+
+	transferCmd.Flags().StringVar(&transferTo, "to", "", "Recipient address or basename")
+	transferCmd.MarkFlagRequired("to")
+}
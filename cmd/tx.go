@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/hughescoin/basenames-cli/base"
+)
+
+// printTxResult prints the outcome of a mined transaction and its decoded logs.
+func printTxResult(result *base.TxResult) {
+	fmt.Printf("Transaction %s mined in block %s (%d confirmation(s))\n", result.Hash.Hex(), result.BlockNumber, result.Confirmations)
+	fmt.Printf("Status: %s\n", txStatus(result))
+	fmt.Printf("Gas used: %d at %s gwei\n", result.GasUsed, base.WeiToGwei(result.EffectiveGasPrice))
+
+	for _, log := range result.Logs {
+		if log.Event == "" {
+			fmt.Printf("  Log from %s\n", log.Address.Hex())
+			continue
+		}
+
+		fmt.Printf("  %s from %s\n", log.Event, log.Address.Hex())
+		keys := make([]string, 0, len(log.Args))
+		for key := range log.Args {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("    %s: %s\n", key, formatLogArg(log.Args[key]))
+		}
+	}
+}
+
+// txOutput is the schema of a mined transaction.
+type txOutput struct {
+	Hash              string      `json:"hash"`
+	Status            string      `json:"status"`
+	BlockNumber       uint64      `json:"blockNumber"`
+	Confirmations     uint64      `json:"confirmations"`
+	GasUsed           uint64      `json:"gasUsed"`
+	EffectiveGasPrice string      `json:"effectiveGasPrice"`
+	Logs              []logOutput `json:"logs"`
+}
+
+type logOutput struct {
+	Address string            `json:"address"`
+	Event   string            `json:"event"`
+	Args    map[string]string `json:"args"`
+}
+
+func newTxOutput(result *base.TxResult) *txOutput {
+	output := &txOutput{
+		Hash:          result.Hash.Hex(),
+		Status:        txStatus(result),
+		Confirmations: result.Confirmations,
+		GasUsed:       result.GasUsed,
+		Logs:          make([]logOutput, 0, len(result.Logs)),
+	}
+	if result.BlockNumber != nil {
+		output.BlockNumber = result.BlockNumber.Uint64()
+	}
+	if result.EffectiveGasPrice != nil {
+		output.EffectiveGasPrice = result.EffectiveGasPrice.String()
+	}
+
+	for _, log := range result.Logs {
+		args := make(map[string]string, len(log.Args))
+		for key, value := range log.Args {
+			args[key] = formatLogArg(value)
+		}
+		output.Logs = append(output.Logs, logOutput{Address: log.Address.Hex(), Event: log.Event, Args: args})
+	}
+	return output
+}
+
+func txStatus(result *base.TxResult) string {
+	if result.Success {
+		return "success"
+	}
+	return "reverted"
+}
+
+// formatLogArg renders a decoded event argument as a string: addresses and
+// hashes as hex, integers in decimal.
+func formatLogArg(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case [32]byte:
+		return hexutil.Encode(v[:])
+	case []byte:
+		return hexutil.Encode(v)
+	case *big.Int:
+		return v.String()
+	}
+	return fmt.Sprint(value)
+}
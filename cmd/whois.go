@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hughescoin/basenames-cli/base"
+	"github.com/spf13/cobra"
+)
+
+type whoisOutput struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+}
+
+var whoisCmd = &cobra.Command{
+	Use:   "whois <address>",
+	Short: "Look up the primary basename of an address",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		if !common.IsHexAddress(args[0]) {
+			return invalidInputf("invalid address %q", args[0])
+		}
+		address := common.HexToAddress(args[0])
+
+		name, err := base.BaseClient.ReverseResolve(ctx, address)
+		if errors.Is(err, base.ErrNoPrimaryName) {
+			return fmt.Errorf("%s has no primary basename: %w", address.Hex(), err)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to look up %s: %w", address.Hex(), err)
+		}
+
+		return printResult(whoisOutput{Address: address.Hex(), Name: name}, func() {
+			fmt.Printf("%s is %s\n", address.Hex(), name)
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(whoisCmd)
+}